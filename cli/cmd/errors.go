@@ -36,6 +36,7 @@ const (
 	ErrAPINotFound
 	ErrFailedToConnect
 	ErrCliNotInAppDir
+	ErrManifestRequiresModel
 )
 
 var errorKinds = []string{
@@ -45,9 +46,10 @@ var errorKinds = []string{
 	"err_api_not_found",
 	"err_failed_to_connect",
 	"err_cli_not_in_app_dir",
+	"err_manifest_requires_model",
 }
 
-var _ = [1]int{}[int(ErrCliNotInAppDir)-(len(errorKinds)-1)] // Ensure list length matches
+var _ = [1]int{}[int(ErrManifestRequiresModel)-(len(errorKinds)-1)] // Ensure list length matches
 
 func (t ErrorKind) String() string {
 	return errorKinds[t]
@@ -126,3 +128,10 @@ func ErrorCliNotInAppDir() error {
 		message: "your current working directory is not in or under a cortex app directory (identified via a top-level app.yaml file)",
 	}
 }
+
+func ErrorManifestRequiresModel() error {
+	return Error{
+		Kind:    ErrManifestRequiresModel,
+		message: "--manifest can only be used with a model (e.g. `cortex get model NAME --manifest`)",
+	}
+}
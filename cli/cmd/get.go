@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"time"
@@ -36,7 +37,10 @@ import (
 	"github.com/cortexlabs/cortex/pkg/operator/api/userconfig"
 )
 
+var flagGetManifest bool
+
 func init() {
+	getCmd.PersistentFlags().BoolVar(&flagGetManifest, "manifest", false, "show the reproducibility manifest of a trained model")
 	addAppNameFlag(getCmd)
 	addEnvFlag(getCmd)
 	addWatchFlag(getCmd)
@@ -61,6 +65,10 @@ func runGet(cmd *cobra.Command, args []string) (string, error) {
 		return "", err
 	}
 
+	if flagGetManifest {
+		return modelManifestStr(args, resourcesRes)
+	}
+
 	switch len(args) {
 	case 0:
 		return allResourcesStr(resourcesRes), nil
@@ -202,6 +210,10 @@ func allResourcesStr(resourcesRes *schema.GetResourcesResponse) string {
 	out += modelsStr(resourcesRes.DataStatuses, resourcesRes.Context)
 	out += titleStr("APIs")
 	out += apisStr(resourcesRes.APIGroupStatuses)
+	if len(resourcesRes.ScheduleStatuses) > 0 {
+		out += titleStr("Schedules")
+		out += schedulesStr(resourcesRes.ScheduleStatuses)
+	}
 	return out
 }
 
@@ -254,14 +266,14 @@ func transformedColumnsStr(dataStatuses map[string]*resource.DataStatus, ctx *co
 }
 
 func trainingDataStr(dataStatuses map[string]*resource.DataStatus, ctx *context.Context) string {
-	if len(ctx.Models) == 0 {
+	trainingDatasets := ctx.Models.GetTrainingDatasets()
+	if len(trainingDatasets) == 0 {
 		return "None\n"
 	}
 
 	strings := make(map[string]string)
-	for _, model := range ctx.Models {
-		name := model.Dataset.Name
-		strings[name] = dataResourceRow(name, model.Dataset, dataStatuses)
+	for name, trainingDataset := range trainingDatasets {
+		strings[name] = dataResourceRow(name, trainingDataset, dataStatuses)
 	}
 	return dataResourcesHeader() + strMapToStr(strings)
 }
@@ -290,6 +302,18 @@ func apisStr(apiGroupStatuses map[string]*resource.APIGroupStatus) string {
 	return apisHeader() + strMapToStr(strings)
 }
 
+func schedulesStr(scheduleStatuses []*resource.ScheduleStatus) string {
+	out := schedulesHeader()
+	for _, scheduleStatus := range scheduleStatuses {
+		name := scheduleStatus.ResourceName
+		if scheduleStatus.ResourceType == resource.AppType {
+			name = "(app)"
+		}
+		out += scheduleRow(name, scheduleStatus) + "\n"
+	}
+	return out
+}
+
 func describePythonPackage(name string, resourcesRes *schema.GetResourcesResponse) (string, error) {
 	pythonPackage := resourcesRes.Context.PythonPackages[name]
 	if pythonPackage == nil {
@@ -306,10 +330,134 @@ func describeRawColumn(name string, resourcesRes *schema.GetResourcesResponse) (
 	}
 	dataStatus := resourcesRes.DataStatuses[rawColumn.GetID()]
 	out := dataStatusSummary(dataStatus)
+
+	if profile := resourcesRes.RawColumnProfiles[rawColumn.GetName()]; profile != nil {
+		out += rawColumnProfileSummary(profile)
+	}
+
+	// the report is also written when validation fails, but not if ingestion failed before the column was validated
+	if dataStatus.ExitCode == resource.ExitCodeDataSucceeded || dataStatus.ExitCode == resource.ExitCodeDataFailed {
+		params := map[string]string{"appName": resourcesRes.Context.App.Name}
+		httpResponse, err := HTTPGet("/raw_column/"+rawColumn.GetID()+"/quality_report", params)
+		if err != nil {
+			return "", err
+		}
+
+		var qualityReportRes schema.GetRawColumnQualityReportResponse
+		err = json.Unmarshal(httpResponse, &qualityReportRes)
+		if err != nil {
+			return "", errors.Wrap(err, "/raw_column/quality_report", "response", string(httpResponse))
+		}
+		if qualityReportRes.Report != nil {
+			out += dataQualityReportSummary(qualityReportRes.Report)
+		}
+	}
+
 	out += resourceStr(rawColumn.GetUserConfig())
 	return out, nil
 }
 
+func rawColumnProfileSummary(profile *context.RawColumnProfile) string {
+	out := titleStr("Profile")
+	out += fmt.Sprintf("Count:           %d\n", profile.Count)
+	out += fmt.Sprintf("Null count:      %d\n", profile.NullCount)
+	if profile.DistinctCount != nil {
+		out += fmt.Sprintf("Distinct count:  ~%d\n", *profile.DistinctCount)
+	}
+	if profile.Min != nil && profile.Max != nil {
+		out += "Min:             " + s.Round(*profile.Min, 4, false) + "\n"
+		out += "Max:             " + s.Round(*profile.Max, 4, false) + "\n"
+	}
+	if profile.Mean != nil {
+		out += "Mean:            " + s.Round(*profile.Mean, 4, false) + "\n"
+	}
+	if profile.Stddev != nil {
+		out += "Stddev:          " + s.Round(*profile.Stddev, 4, false) + "\n"
+	}
+
+	if len(profile.TopValues) > 0 {
+		var maxCount int64
+		for _, valueCount := range profile.TopValues {
+			if valueCount.Count > maxCount {
+				maxCount = valueCount.Count
+			}
+		}
+		out += "\n" + fmt.Sprintf("%-35s%s\n", "VALUE", "COUNT")
+		for _, valueCount := range profile.TopValues {
+			value := fmt.Sprint(valueCount.Value)
+			if len(value) > 32 {
+				value = value[:29] + "..."
+			}
+			out += fmt.Sprintf("%-35s%-12d%s\n", value, valueCount.Count, histogramBar(valueCount.Count, maxCount))
+		}
+	}
+
+	if len(profile.Histogram) > 0 {
+		var maxCount int64
+		for _, bin := range profile.Histogram {
+			if bin.Count > maxCount {
+				maxCount = bin.Count
+			}
+		}
+		out += "\n" + fmt.Sprintf("%-35s%s\n", "RANGE", "COUNT")
+		for i, bin := range profile.Histogram {
+			closingBracket := ")"
+			if i == len(profile.Histogram)-1 {
+				closingBracket = "]"
+			}
+			binRange := "[" + s.Round(bin.Start, 4, false) + ", " + s.Round(bin.End, 4, false) + closingBracket
+			out += fmt.Sprintf("%-35s%-12d%s\n", binRange, bin.Count, histogramBar(bin.Count, maxCount))
+		}
+	}
+
+	return out
+}
+
+// histogramBar is scaled so that maxCount fills the full width, and any non-zero count is visible
+func histogramBar(count int64, maxCount int64) string {
+	barWidth := 40
+	if maxCount == 0 {
+		return ""
+	}
+	length := int(math.Round(float64(count) / float64(maxCount) * float64(barWidth)))
+	if length == 0 && count > 0 {
+		length = 1
+	}
+	return strings.Repeat("█", length)
+}
+
+func dataQualityReportSummary(report *context.DataQualityReport) string {
+	out := titleStr("Data quality")
+	if report.Passed {
+		out += "Status:           passed\n"
+	} else {
+		out += "Status:           failed\n"
+	}
+	out += fmt.Sprintf("Rows checked:     %d\n", report.NumRows)
+	out += "Error threshold:  " + s.Round(report.ErrorThreshold, 4, false) + "\n"
+
+	if len(report.Violations) == 0 {
+		out += "\nNo violations\n"
+		return out
+	}
+
+	out += "\n" + fmt.Sprintf("%-20s%-14s%-12s%s\n", "CONSTRAINT", "VIOLATIONS", "FRACTION", "CONDITION")
+	for _, violation := range report.Violations {
+		out += fmt.Sprintf("%-20s%-14d%-12s%s\n", violation.Constraint, violation.NumRows, s.Round(violation.Fraction, 4, false), violation.Description)
+	}
+
+	for _, violation := range report.Violations {
+		if len(violation.SampleRows) == 0 {
+			continue
+		}
+		out += "\nSample rows violating " + violation.Constraint + ":\n"
+		for _, row := range violation.SampleRows {
+			out += "  " + s.ObjFlat(row) + "\n"
+		}
+	}
+	return out
+}
+
 func describeAggregate(name string, resourcesRes *schema.GetResourcesResponse) (string, error) {
 	aggregate := resourcesRes.Context.Aggregates[name]
 	if aggregate == nil {
@@ -369,10 +517,102 @@ func describeModel(name string, resourcesRes *schema.GetResourcesResponse) (stri
 	}
 	dataStatus := resourcesRes.DataStatuses[model.ID]
 	out := dataStatusSummary(dataStatus)
+	for _, scheduleStatus := range resourcesRes.ScheduleStatuses {
+		if scheduleStatus.ResourceType == resource.ModelType && scheduleStatus.ResourceName == name {
+			out += scheduleSummary(scheduleStatus)
+		}
+	}
+
+	if model.Lineage != nil && model.Lineage.WarmStartModelID != "" {
+		out += titleStr("Lineage")
+		out += "Warm-started from:  " + model.Lineage.WarmStartModelID + " (" + *model.WarmStart + ")\n"
+	}
+
+	if (model.NumFolds() > 0 || model.Evaluation.FeatureImportance) && dataStatus.ExitCode == resource.ExitCodeDataSucceeded {
+		params := map[string]string{"appName": resourcesRes.Context.App.Name}
+		httpResponse, err := HTTPGet("/model/"+model.ID+"/metrics", params)
+		if err != nil {
+			return "", err
+		}
+
+		var modelMetricsRes schema.GetModelMetricsResponse
+		err = json.Unmarshal(httpResponse, &modelMetricsRes)
+		if err != nil {
+			return "", errors.Wrap(err, "/model/metrics", "response", string(httpResponse))
+		}
+
+		if modelMetricsRes.CrossValidation != nil {
+			out += crossValidationSummary(modelMetricsRes.CrossValidation)
+		}
+		if modelMetricsRes.FeatureImportance != nil {
+			out += featureImportanceSummary(modelMetricsRes.FeatureImportance)
+		}
+	}
+
 	out += resourceStr(model.Model)
 	return out, nil
 }
 
+// modelManifestStr expects either MODEL_NAME or model MODEL_NAME as args
+func modelManifestStr(args []string, resourcesRes *schema.GetResourcesResponse) (string, error) {
+	var name string
+	switch len(args) {
+	case 1:
+		name = args[0]
+	case 2:
+		resourceType, err := resource.VisibleResourceTypeFromPrefix(args[0])
+		if err != nil || resourceType != resource.ModelType {
+			return "", ErrorManifestRequiresModel()
+		}
+		name = args[1]
+	default:
+		return "", ErrorManifestRequiresModel()
+	}
+
+	model := resourcesRes.Context.Models[name]
+	if model == nil {
+		return "", ErrorManifestRequiresModel()
+	}
+
+	params := map[string]string{"appName": resourcesRes.Context.App.Name}
+	httpResponse, err := HTTPGet("/model/"+model.ID+"/manifest", params)
+	if err != nil {
+		return "", err
+	}
+
+	var modelManifestRes schema.GetModelManifestResponse
+	err = json.Unmarshal(httpResponse, &modelManifestRes)
+	if err != nil {
+		return "", errors.Wrap(err, "/model/manifest", "response", string(httpResponse))
+	}
+
+	return json.MarshalJSONStr(modelManifestRes.Manifest)
+}
+
+func crossValidationSummary(metrics *resource.CrossValidationMetrics) string {
+	var names []string
+	for name := range metrics.Mean {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := titleStr(fmt.Sprintf("Cross-validation (%d folds)", len(metrics.Folds)))
+	out += fmt.Sprintf("%-35s%-24s%s\n", "METRIC", "MEAN", "VARIANCE")
+	for _, name := range names {
+		out += fmt.Sprintf("%-35s%-24s%s\n", name, s.Round(metrics.Mean[name], 4, false), s.Round(metrics.Variance[name], 6, false))
+	}
+	return out
+}
+
+func featureImportanceSummary(featureImportance *resource.FeatureImportance) string {
+	out := titleStr("Feature importance")
+	out += fmt.Sprintf("%-6s%-35s%s\n", "RANK", "COLUMN", "IMPORTANCE")
+	for i, columnName := range featureImportance.Ranked() {
+		out += fmt.Sprintf("%-6d%-35s%s\n", i+1, columnName, s.Round(featureImportance.Importance[columnName], 4, false))
+	}
+	return out
+}
+
 func describeAPI(name string, resourcesRes *schema.GetResourcesResponse) (string, error) {
 	groupStatus := resourcesRes.APIGroupStatuses[name]
 	if groupStatus == nil {
@@ -435,6 +675,20 @@ func dataStatusSummary(dataStatus *resource.DataStatus) string {
 	out += "Status:               " + dataStatus.Message() + "\n"
 	out += "Workload started at:  " + libtime.LocalTimestamp(dataStatus.Start) + "\n"
 	out += "Workload ended at:    " + libtime.LocalTimestamp(dataStatus.End) + "\n"
+	if dataStatus.MaxAttempts > 1 {
+		out += fmt.Sprintf("Attempt:              %d of %d\n", dataStatus.Attempt, dataStatus.MaxAttempts)
+	}
+	return out
+}
+
+func scheduleSummary(scheduleStatus *resource.ScheduleStatus) string {
+	out := titleStr("Schedule")
+	out += "Schedule:              " + scheduleStatus.Schedule + "\n"
+	out += "Next scheduled run:    " + libtime.LocalTimestamp(scheduleStatus.NextRun) + "\n"
+	out += "Last scheduled run:    " + libtime.LocalTimestamp(scheduleStatus.LastRun) + "\n"
+	if scheduleStatus.LastSkippedRun != nil {
+		out += "Last skipped run:      " + libtime.LocalTimestamp(scheduleStatus.LastSkippedRun) + " (previous run still in progress)\n"
+	}
 	return out
 }
 
@@ -494,6 +748,23 @@ func apisHeader() string {
 	return stringifyRow("NAME", "STATUS", "LAST UPDATE") + "\n"
 }
 
+func scheduleRow(name string, scheduleStatus *resource.ScheduleStatus) string {
+	if len(name) > 33 {
+		name = name[0:30] + "..."
+	}
+	schedule := scheduleStatus.Schedule
+	if len(schedule) > 23 {
+		schedule = schedule[0:20] + "..."
+	}
+	nextRun := libtime.LocalTimestamp(scheduleStatus.NextRun)
+	lastRun := libtime.LocalTimestamp(scheduleStatus.LastRun)
+	return fmt.Sprintf("%-35s%-24s%-26s%s", name, schedule, nextRun, lastRun)
+}
+
+func schedulesHeader() string {
+	return fmt.Sprintf("%-35s%-24s%-26s%s", "NAME", "SCHEDULE", "NEXT RUN", "LAST RUN") + "\n"
+}
+
 func stringifyRow(name string, status string, timeSince string) string {
 	return fmt.Sprintf("%-35s%-24s%s", name, status, timeSince)
 }
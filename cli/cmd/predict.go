@@ -19,7 +19,9 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -33,26 +35,48 @@ import (
 	"github.com/cortexlabs/cortex/pkg/operator/api/resource"
 )
 
+var flagPredictExplain bool
+
 func init() {
+	predictCmd.PersistentFlags().BoolVar(&flagPredictExplain, "explain", false, "show the contribution of each feature column to the predictions")
 	addAppNameFlag(predictCmd)
 	addEnvFlag(predictCmd)
 }
 
 type PredictResponse struct {
-	ResourceID                string                     `json:"resource_id"`
-	ClassificationPredictions []ClassificationPrediction `json:"classification_predictions"`
-	RegressionPredictions     []RegressionPrediction     `json:"regression_predictions"`
+	ResourceID                          string                               `json:"resource_id"`
+	ClassificationPredictions           []ClassificationPrediction           `json:"classification_predictions"`
+	RegressionPredictions               []RegressionPrediction               `json:"regression_predictions"`
+	MultiLabelClassificationPredictions []MultiLabelClassificationPrediction `json:"multi_label_classification_predictions"`
+	RankingPredictions                  []RankingPrediction                  `json:"ranking_predictions"`
+	CustomPredictions                   []interface{}                        `json:"custom_predictions"`
+	MultiTargetPredictions              []map[string]map[string]interface{}  `json:"multi_target_predictions"`
 }
 
 type ClassificationPrediction struct {
-	PredictedClass         int         `json:"predicted_class"`
-	PredictedClassReversed interface{} `json:"predicted_class_reversed"`
-	Probabilities          []float64   `json:"probabilities"`
+	PredictedClass         int                `json:"predicted_class"`
+	PredictedClassReversed interface{}        `json:"predicted_class_reversed"`
+	Probabilities          []float64          `json:"probabilities"`
+	FeatureContributions   map[string]float64 `json:"feature_contributions"`
 }
 
 type RegressionPrediction struct {
-	PredictedValue         float64     `json:"predicted_value"`
-	PredictedValueReversed interface{} `json:"predicted_value_reversed"`
+	PredictedValue         float64            `json:"predicted_value"`
+	PredictedValueReversed interface{}        `json:"predicted_value_reversed"`
+	FeatureContributions   map[string]float64 `json:"feature_contributions"`
+}
+
+type MultiLabelClassificationPrediction struct {
+	PredictedClasses         []int       `json:"predicted_classes"`
+	PredictedClassesReversed interface{} `json:"predicted_classes_reversed"`
+	Probabilities            []float64   `json:"probabilities"`
+}
+
+type RankingPrediction struct {
+	PredictedScore         float64            `json:"predicted_score"`
+	PredictedScoreReversed interface{}        `json:"predicted_score_reversed"`
+	Rank                   int                `json:"rank"`
+	FeatureContributions   map[string]float64 `json:"feature_contributions"`
 }
 
 var predictCmd = &cobra.Command{
@@ -79,7 +103,7 @@ var predictCmd = &cobra.Command{
 
 		apiPath := apiGroupStatus.ActiveStatus.Path
 		apiURL := urls.Join(resourcesRes.APIsBaseURL, apiPath)
-		predictResponse, err := makePredictRequest(apiURL, samplesJSONPath)
+		predictResponse, err := makePredictRequest(apiURL, samplesJSONPath, flagPredictExplain)
 		if err != nil {
 			if strings.Contains(err.Error(), "503 Service Temporarily Unavailable") || strings.Contains(err.Error(), "502 Bad Gateway") {
 				errors.Exit(ErrorAPINotReady(apiName, resource.StatusUpdating.Message()))
@@ -123,14 +147,166 @@ var predictCmd = &cobra.Command{
 				}
 			}
 		}
+		if predictResponse.MultiLabelClassificationPredictions != nil {
+			if len(predictResponse.MultiLabelClassificationPredictions) == 1 {
+				fmt.Println("Predicted classes:")
+			} else {
+				fmt.Println("Predicted classes (one line per sample):")
+			}
+			for _, prediction := range predictResponse.MultiLabelClassificationPredictions {
+				if prediction.PredictedClassesReversed != nil {
+					json, _ := json.Marshal(prediction.PredictedClassesReversed)
+					fmt.Println(string(json))
+				} else {
+					json, _ := json.Marshal(prediction.PredictedClasses)
+					fmt.Println(string(json))
+				}
+			}
+		}
+		if predictResponse.RankingPredictions != nil {
+			if len(predictResponse.RankingPredictions) == 1 {
+				fmt.Println("Predicted score:")
+			} else {
+				fmt.Println("Predicted scores (rank: score):")
+			}
+			for _, prediction := range predictResponse.RankingPredictions {
+				score := s.Round(prediction.PredictedScore, 2, true)
+				if prediction.PredictedScoreReversed != nil {
+					json, _ := json.Marshal(prediction.PredictedScoreReversed)
+					score = s.TrimPrefixAndSuffix(string(json), "\"")
+				}
+				if len(predictResponse.RankingPredictions) == 1 {
+					fmt.Println(score)
+				} else {
+					fmt.Println(s.Int(prediction.Rank) + ": " + score)
+				}
+			}
+		}
+		if predictResponse.CustomPredictions != nil {
+			if len(predictResponse.CustomPredictions) == 1 {
+				fmt.Println("Prediction:")
+			} else {
+				fmt.Println("Predictions:")
+			}
+			for _, prediction := range predictResponse.CustomPredictions {
+				json, _ := json.Marshal(prediction)
+				fmt.Println(string(json))
+			}
+		}
+		if predictResponse.MultiTargetPredictions != nil {
+			if len(predictResponse.MultiTargetPredictions) == 1 {
+				fmt.Println("Prediction:")
+			} else {
+				fmt.Println("Predictions:")
+			}
+			for i, prediction := range predictResponse.MultiTargetPredictions {
+				indent := ""
+				if len(predictResponse.MultiTargetPredictions) > 1 {
+					fmt.Println("sample " + s.Int(i+1) + ":")
+					indent = "  "
+				}
+				targetColumns := make([]string, 0, len(prediction))
+				for targetColumn := range prediction {
+					targetColumns = append(targetColumns, targetColumn)
+				}
+				sort.Strings(targetColumns)
+				for _, targetColumn := range targetColumns {
+					fmt.Println(indent + targetColumn + ": " + targetPredictionStr(prediction[targetColumn]))
+				}
+			}
+		}
+
+		if flagPredictExplain {
+			fmt.Print(featureContributionsStr(predictResponse.featureContributions()))
+		}
 	},
 }
 
-func makePredictRequest(apiURL string, samplesJSONPath string) (*PredictResponse, error) {
+func (predictResponse *PredictResponse) featureContributions() []map[string]float64 {
+	var contributions []map[string]float64
+	for _, prediction := range predictResponse.ClassificationPredictions {
+		contributions = append(contributions, prediction.FeatureContributions)
+	}
+	for _, prediction := range predictResponse.RegressionPredictions {
+		contributions = append(contributions, prediction.FeatureContributions)
+	}
+	for _, prediction := range predictResponse.RankingPredictions {
+		contributions = append(contributions, prediction.FeatureContributions)
+	}
+	return contributions
+}
+
+// featureContributionsStr lists each sample's feature columns by the size of their contribution
+func featureContributionsStr(contributions []map[string]float64) string {
+	out := "\nFeature contributions:\n"
+	for i, sampleContributions := range contributions {
+		indent := ""
+		if len(contributions) > 1 {
+			out += "sample " + s.Int(i+1) + ":\n"
+			indent = "  "
+		}
+		columnNames := make([]string, 0, len(sampleContributions))
+		for columnName := range sampleContributions {
+			columnNames = append(columnNames, columnName)
+		}
+		sort.Slice(columnNames, func(i, j int) bool {
+			return math.Abs(sampleContributions[columnNames[i]]) > math.Abs(sampleContributions[columnNames[j]])
+		})
+		for _, columnName := range columnNames {
+			out += fmt.Sprintf("%s%-35s%s\n", indent, columnName, s.Round(sampleContributions[columnName], 4, false))
+		}
+	}
+	return out
+}
+
+var targetPredictionKeys = []string{
+	"predicted_class",
+	"predicted_value",
+	"predicted_classes",
+	"predicted_score",
+}
+
+// targetPredictionStr formats the prediction of one target in a multi-target response, preferring the reversed value
+func targetPredictionStr(prediction map[string]interface{}) string {
+	for _, key := range targetPredictionKeys {
+		value, ok := prediction[key]
+		if !ok {
+			continue
+		}
+		if reversed := prediction[key+"_reversed"]; reversed != nil {
+			value = reversed
+		}
+		if floatVal, ok := value.(float64); ok && key != "predicted_class" {
+			str := s.Round(floatVal, 2, true)
+			if rank, ok := prediction["rank"].(float64); ok {
+				str += " (rank " + s.Int(int(rank)) + ")"
+			}
+			return str
+		}
+		json, _ := json.Marshal(value)
+		return s.TrimPrefixAndSuffix(string(json), "\"")
+	}
+
+	json, _ := json.Marshal(prediction)
+	return string(json)
+}
+
+func makePredictRequest(apiURL string, samplesJSONPath string, explain bool) (*PredictResponse, error) {
 	samplesBytes, err := files.ReadFileBytes(samplesJSONPath)
 	if err != nil {
 		errors.Exit(err)
 	}
+	if explain {
+		var samples map[string]interface{}
+		if err := json.Unmarshal(samplesBytes, &samples); err != nil {
+			errors.Exit(errors.Wrap(err, samplesJSONPath))
+		}
+		samples["explain"] = true
+		samplesBytes, err = json.Marshal(samples)
+		if err != nil {
+			errors.Exit(err)
+		}
+	}
 	payload := bytes.NewBuffer(samplesBytes)
 	req, err := http.NewRequest("POST", apiURL, payload)
 	if err != nil {
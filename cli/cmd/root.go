@@ -75,6 +75,8 @@ func Execute() {
 	rootCmd.AddCommand(getCmd)
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(logsCmd)
+	rootCmd.AddCommand(runsCmd)
+	rootCmd.AddCommand(tensorboardCmd)
 
 	rootCmd.AddCommand(configureCmd)
 	rootCmd.AddCommand(completionCmd)
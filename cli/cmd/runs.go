@@ -0,0 +1,130 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	libtime "github.com/cortexlabs/cortex/pkg/lib/time"
+	"github.com/cortexlabs/cortex/pkg/operator/api/context"
+	"github.com/cortexlabs/cortex/pkg/operator/api/schema"
+)
+
+var flagRunsFilters []string
+var flagRunsSort string
+var flagRunsAscending bool
+
+func init() {
+	runsCmd.PersistentFlags().StringSliceVarP(&flagRunsFilters, "filter", "f", nil, "only show runs with matching metrics (e.g. --filter \"accuracy>=0.9\")")
+	runsCmd.PersistentFlags().StringVarP(&flagRunsSort, "sort", "s", "", "sort runs by a metric (highest first)")
+	runsCmd.PersistentFlags().BoolVar(&flagRunsAscending, "ascending", false, "sort runs from lowest to highest")
+	addAppNameFlag(runsCmd)
+	addEnvFlag(runsCmd)
+}
+
+var runsCmd = &cobra.Command{
+	Use:   "runs MODEL_NAME",
+	Short: "get the training history of a model",
+	Long:  "Get the training history of a model.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		appName, err := AppNameFromFlagOrConfig()
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		params := map[string]string{
+			"appName":   appName,
+			"modelName": args[0],
+			"filter":    strings.Join(flagRunsFilters, ","),
+			"sort":      flagRunsSort,
+			"ascending": s.Bool(flagRunsAscending),
+		}
+		httpResponse, err := HTTPGet("/model/runs", params)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		var modelRunsRes schema.GetModelRunsResponse
+		err = json.Unmarshal(httpResponse, &modelRunsRes)
+		if err != nil {
+			errors.Exit(err, "/model/runs", "response", string(httpResponse))
+		}
+
+		if len(modelRunsRes.Runs) == 0 {
+			fmt.Println("no runs found")
+			return
+		}
+
+		out := ""
+		for _, run := range modelRunsRes.Runs {
+			out += modelRunStr(run)
+		}
+		fmt.Print(out)
+	},
+}
+
+func modelRunStr(run *context.ModelRun) string {
+	out := titleStr("Model " + run.ModelID)
+	out += "Started at:       " + libtime.LocalTimestamp(&run.Start) + "\n"
+	out += "Duration:         " + libtime.Difference(&run.Start, &run.End) + "\n"
+	out += "Dataset version:  " + run.DatasetVersion + "\n"
+	out += "Feature columns:  " + strings.Join(run.FeatureColumns, ", ") + "\n"
+	if run.Compute != nil {
+		out += "Compute:          " + computeStr(run) + "\n"
+	}
+	if len(run.Hparams) > 0 {
+		out += "Hparams:          " + s.Obj(run.Hparams) + "\n"
+	}
+
+	var names []string
+	for name := range run.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out += "\n"
+	out += fmt.Sprintf("%-35s%s\n", "METRIC", "VALUE")
+	for _, name := range names {
+		out += fmt.Sprintf("%-35s%s\n", name, s.Round(run.Metrics[name], 4, false))
+	}
+	return out
+}
+
+func computeStr(run *context.ModelRun) string {
+	var parts []string
+	if run.Compute.CPU != nil {
+		parts = append(parts, "cpu: "+run.Compute.CPU.String())
+	}
+	if run.Compute.Mem != nil {
+		parts = append(parts, "mem: "+run.Compute.Mem.String())
+	}
+	if run.Compute.GPU != nil {
+		parts = append(parts, "gpu: "+s.Int64(*run.Compute.GPU))
+	}
+	if len(parts) == 0 {
+		return "default"
+	}
+	return strings.Join(parts, ", ")
+}
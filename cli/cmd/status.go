@@ -138,11 +138,11 @@ func transformedColumnStatusesStr(dataStatuses map[string]*resource.DataStatus,
 }
 
 func trainingDatasetStatusesStr(dataStatuses map[string]*resource.DataStatus, ctx *context.Context) string {
-	var statuses = make([]resource.Status, len(ctx.Models))
-	i := 0
+	var statuses []resource.Status
 	for _, model := range ctx.Models {
-		statuses[i] = dataStatuses[model.Dataset.GetID()]
-		i++
+		if model.Dataset != nil {
+			statuses = append(statuses, dataStatuses[model.Dataset.GetID()])
+		}
 	}
 	return "Training Datasets:     " + StatusStr(statuses)
 }
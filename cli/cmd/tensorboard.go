@@ -0,0 +1,80 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/operator/api/schema"
+)
+
+var flagStopTensorBoard bool
+
+func init() {
+	tensorboardCmd.PersistentFlags().BoolVar(&flagStopTensorBoard, "stop", false, "stop the model's TensorBoard")
+	addAppNameFlag(tensorboardCmd)
+	addEnvFlag(tensorboardCmd)
+}
+
+var tensorboardCmd = &cobra.Command{
+	Use:   "tensorboard MODEL_NAME",
+	Short: "view a model's training summaries in TensorBoard",
+	Long:  "View a model's training summaries in TensorBoard (it is stopped automatically after it has not been viewed for an hour).",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		appName, err := AppNameFromFlagOrConfig()
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		params := map[string]string{
+			"appName":   appName,
+			"modelName": args[0],
+		}
+
+		if flagStopTensorBoard {
+			httpResponse, err := HTTPPostJSONData("/tensorboard/stop", nil, params)
+			if err != nil {
+				errors.Exit(err)
+			}
+
+			var stopResponse schema.StopTensorBoardResponse
+			err = json.Unmarshal(httpResponse, &stopResponse)
+			if err != nil {
+				errors.Exit(err, "/tensorboard/stop", "response", string(httpResponse))
+			}
+			fmt.Println(stopResponse.Message)
+			return
+		}
+
+		httpResponse, err := HTTPPostJSONData("/tensorboard/start", nil, params)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		var startResponse schema.StartTensorBoardResponse
+		err = json.Unmarshal(httpResponse, &startResponse)
+		if err != nil {
+			errors.Exit(err, "/tensorboard/start", "response", string(httpResponse))
+		}
+		fmt.Println(startResponse.URL)
+	},
+}
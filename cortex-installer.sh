@@ -142,6 +142,8 @@ export CORTEX_IMAGE_TF_TRAIN_GPU="${CORTEX_IMAGE_TF_TRAIN_GPU:-cortexlabs/tf-tra
 export AWS_ACCESS_KEY_ID="${AWS_ACCESS_KEY_ID:-""}"
 export AWS_SECRET_ACCESS_KEY="${AWS_SECRET_ACCESS_KEY:-""}"
 export CORTEX_ENABLE_TELEMETRY=${CORTEX_ENABLE_TELEMETRY:-""}
+export CORTEX_FRAMEWORKS="${CORTEX_FRAMEWORKS:-""}"
+export CORTEX_IMAGE_REGISTRIES="${CORTEX_IMAGE_REGISTRIES:-""}"
 
 ################
 ### CHECK OS ###
@@ -309,6 +311,8 @@ function setup_configmap() {
     --from-literal='IMAGE_TF_TRAIN_GPU'=$CORTEX_IMAGE_TF_TRAIN_GPU \
     --from-literal='IMAGE_TF_SERVE_GPU'=$CORTEX_IMAGE_TF_SERVE_GPU \
     --from-literal='ENABLE_TELEMETRY'=$CORTEX_ENABLE_TELEMETRY \
+    --from-literal='FRAMEWORKS'="$CORTEX_FRAMEWORKS" \
+    --from-literal='IMAGE_REGISTRIES'=$CORTEX_IMAGE_REGISTRIES \
     -o yaml --dry-run | kubectl apply -f - >/dev/null
 }
 
@@ -36,6 +36,9 @@ var (
 	RequirementsTxt = "requirements.txt"
 	PackageDir      = "packages"
 
+	TensorFlowFramework   = "tensorflow"
+	ClassWeightAggregator = "cortex.class_distribution_int"
+
 	AppsDir             = "apps"
 	DataDir             = "data"
 	RawDataDir          = "data_raw"
@@ -51,6 +54,10 @@ var (
 	ResourceStatusesDir = "resource_statuses"
 	WorkloadSpecsDir    = "workload_specs"
 	LogPrefixesDir      = "log_prefixes"
+	ImageDigestsDir     = "image_digests"
+	SchedulesDir        = "schedules"
+	ModelLineageDir     = "model_lineage"
+	ModelRunsDir        = "model_runs"
 
 	TelemetryURL = "https://telemetry.cortexlabs.dev"
 )
@@ -190,6 +190,26 @@ func (c *Client) ReadBytesFromS3(key string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+func (c *Client) ListS3Prefix(prefix string) ([]string, error) {
+	listObjectsInput := &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.Bucket),
+		Prefix: aws.String(prefix),
+	}
+
+	var keys []string
+	err := c.s3Client.ListObjectsV2Pages(listObjectsInput,
+		func(listObjectsOutput *s3.ListObjectsV2Output, lastPage bool) bool {
+			for _, object := range listObjectsOutput.Contents {
+				keys = append(keys, *object.Key)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, prefix)
+	}
+	return keys, nil
+}
+
 func (c *Client) DeleteFromS3ByPrefix(prefix string, continueIfFailure bool) error {
 	listObjectsInput := &s3.ListObjectsV2Input{
 		Bucket:  aws.String(c.Bucket),
@@ -17,6 +17,8 @@ limitations under the License.
 package k8s
 
 import (
+	"fmt"
+
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -27,16 +29,23 @@ import (
 const JobSuccessCondition = "status.succeeded > 0"
 const JobFailureCondition = "status.failed > 0"
 
+// JobFailureConditionWithRetries is the failure condition for jobs with a non-zero BackoffLimit
+func JobFailureConditionWithRetries(backoffLimit int32) string {
+	return fmt.Sprintf("status.failed > %d", backoffLimit)
+}
+
 var jobTypeMeta = metav1.TypeMeta{
 	APIVersion: "batch/v1",
 	Kind:       "Job",
 }
 
 type JobSpec struct {
-	Name      string
-	Namespace string
-	PodSpec   PodSpec
-	Labels    map[string]string
+	Name         string
+	Namespace    string
+	PodSpec      PodSpec
+	Labels       map[string]string
+	BackoffLimit int32  // number of times to retry failed pods
+	MaxDuration  *int64 // seconds (including retries) before the job is terminated
 }
 
 func Job(spec *JobSpec) *batchv1.Job {
@@ -51,7 +60,7 @@ func Job(spec *JobSpec) *batchv1.Job {
 	}
 
 	parallelism := int32(1)
-	backoffLimit := int32(0)
+	backoffLimit := spec.BackoffLimit
 	completions := int32(1)
 
 	job := &batchv1.Job{
@@ -62,9 +71,10 @@ func Job(spec *JobSpec) *batchv1.Job {
 			Labels:    spec.Labels,
 		},
 		Spec: batchv1.JobSpec{
-			BackoffLimit: &backoffLimit,
-			Parallelism:  &parallelism,
-			Completions:  &completions,
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: spec.MaxDuration,
+			Parallelism:           &parallelism,
+			Completions:           &completions,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      spec.PodSpec.Name,
@@ -78,6 +88,16 @@ func Job(spec *JobSpec) *batchv1.Job {
 	return job
 }
 
+// JobTimedOut returns true if the job was terminated because it exceeded its MaxDuration
+func JobTimedOut(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue && condition.Reason == "DeadlineExceeded" {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Client) CreateJob(spec *JobSpec) (*batchv1.Job, error) {
 	job, err := c.jobClient.Create(Job(spec))
 	if err != nil {
@@ -17,6 +17,7 @@ limitations under the License.
 package k8s
 
 import (
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -95,6 +96,22 @@ func GetPodLastContainerStartTime(pod *corev1.Pod) *time.Time {
 	return startTime
 }
 
+// GetPodImageDigests returns the digest of each of the pod's images, once its containers have started
+func GetPodImageDigests(pod *corev1.Pod) map[string]string {
+	digests := make(map[string]string)
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.ImageID == "" {
+			continue
+		}
+		digest := containerStatus.ImageID
+		if index := strings.Index(digest, "://"); index >= 0 {
+			digest = digest[index+3:] // e.g. docker-pullable://
+		}
+		digests[containerStatus.Image] = digest
+	}
+	return digests
+}
+
 func GetPodStatus(pod *corev1.Pod) string {
 	if pod == nil {
 		return PodStatusUnknown
@@ -254,6 +271,19 @@ func (c *Client) ListPodsByLabel(labelKey string, labelValue string) ([]corev1.P
 	return c.ListPodsByLabels(map[string]string{labelKey: labelValue})
 }
 
+// GetPodLogs returns the logs which the pod's container wrote in the last sinceSeconds seconds
+func (c *Client) GetPodLogs(name string, containerName string, sinceSeconds int64) (string, error) {
+	logOpts := &corev1.PodLogOptions{
+		Container:    containerName,
+		SinceSeconds: &sinceSeconds,
+	}
+	logs, err := c.podClient.GetLogs(name, logOpts).DoRaw()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(logs), nil
+}
+
 func PodMap(pods []corev1.Pod) map[string]corev1.Pod {
 	podMap := map[string]corev1.Pod{}
 	for _, pod := range pods {
@@ -26,6 +26,7 @@ type Aggregator struct {
 	*userconfig.Aggregator
 	*ResourceFields
 	Namespace *string `json:"namespace"`
+	ImplID    string  `json:"impl_id"`
 	ImplKey   string  `json:"impl_key"`
 }
 
@@ -46,8 +46,10 @@ type Context struct {
 }
 
 type RawDataset struct {
-	Key         string `json:"key"`
-	MetadataKey string `json:"metadata_key"`
+	Key                  string `json:"key"`
+	MetadataKey          string `json:"metadata_key"`
+	QualityReportsPrefix string `json:"quality_reports_prefix"`
+	ProfilesKey          string `json:"profiles_key"`
 }
 
 type Resource interface {
@@ -122,7 +124,10 @@ func (ctx *Context) DataComputedResources() []ComputedResource {
 		resources = append(resources, transformedColumn)
 	}
 	for _, model := range ctx.Models {
-		resources = append(resources, model, model.Dataset)
+		resources = append(resources, model)
+		if model.Dataset != nil {
+			resources = append(resources, model.Dataset)
+		}
 	}
 	return resources
 }
@@ -230,7 +235,9 @@ func (ctx *Context) VisibleResourcesMap() map[string][]ComputedResource {
 	}
 	for name, model := range ctx.Models {
 		resources[name] = append(resources[name], model)
-		resources[model.Dataset.Name] = append(resources[model.Dataset.Name], model.Dataset)
+		if model.Dataset != nil {
+			resources[model.Dataset.Name] = append(resources[model.Dataset.Name], model.Dataset)
+		}
 	}
 	for name, api := range ctx.APIs {
 		resources[name] = append(resources[name], api)
@@ -0,0 +1,42 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"path/filepath"
+)
+
+// DataQualityReport summarizes the constraint violations found in a raw column when it was validated, it is saved for every validated raw column
+type DataQualityReport struct {
+	ColumnName     string                  `json:"column_name"`
+	NumRows        int64                   `json:"num_rows"`
+	ErrorThreshold float64                 `json:"error_threshold"`
+	Passed         bool                    `json:"passed"`
+	Violations     []*DataQualityViolation `json:"violations"`
+}
+
+type DataQualityViolation struct {
+	Constraint  string                   `json:"constraint"`  // the config key of the constraint, e.g. "min" or "pattern"
+	Description string                   `json:"description"` // the condition which valid rows satisfy
+	NumRows     int64                    `json:"num_rows"`    // the number of rows which violate the constraint
+	Fraction    float64                  `json:"fraction"`    // the fraction of rows which violate the constraint
+	SampleRows  []map[string]interface{} `json:"sample_rows"` // a few of the violating rows
+}
+
+func (rawDataset *RawDataset) QualityReportKey(rawColumnID string) string {
+	return filepath.Join(rawDataset.QualityReportsPrefix, rawColumnID+".json")
+}
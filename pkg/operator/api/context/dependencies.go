@@ -55,7 +55,7 @@ func (ctx *Context) DirectComputedResourceDependencies(resourceID string) strset
 		if model.ID == resourceID {
 			return ctx.modelDependencies(model)
 		}
-		if model.Dataset.ID == resourceID {
+		if model.Dataset != nil && model.Dataset.ID == resourceID {
 			return ctx.trainingDatasetDependencies(model)
 		}
 	}
@@ -132,7 +132,9 @@ func (ctx *Context) modelDependencies(model *Model) strset.Set {
 		dependencies.Add(pythonPackage.GetID())
 	}
 
-	dependencies.Add(model.Dataset.ID)
+	if model.Dataset != nil {
+		dependencies.Add(model.Dataset.ID)
+	}
 	for _, aggregate := range model.Aggregates {
 		dependencies.Add(ctx.Aggregates[aggregate].GetID())
 	}
@@ -141,5 +143,12 @@ func (ctx *Context) modelDependencies(model *Model) strset.Set {
 
 func (ctx *Context) apiDependencies(api *API) strset.Set {
 	model := ctx.Models[api.ModelName]
-	return strset.New(model.ID)
+	dependencies := strset.New(model.ID)
+	if model.IsExternal() {
+		// External models aren't trained on a dataset, so wait for the columns (and their aggregates) used at serving time
+		for _, columnName := range model.AllColumnNames() {
+			dependencies.Add(ctx.GetColumn(columnName).GetID())
+		}
+	}
+	return dependencies
 }
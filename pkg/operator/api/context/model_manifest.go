@@ -0,0 +1,84 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"time"
+)
+
+// ModelManifest records everything that produced a trained model, it is saved once the model is trained
+type ModelManifest struct {
+	ModelID        string            `json:"model_id"`
+	ModelName      string            `json:"model_name"`
+	DatasetVersion string            `json:"dataset_version"`
+	DatasetID      string            `json:"dataset_id"`
+	TFRandomSeed   int64             `json:"tf_random_seed"`
+	ImplID         string            `json:"impl_id"`
+	Transformers   map[string]string `json:"transformers"`    // name -> implementation ID
+	Aggregators    map[string]string `json:"aggregators"`     // name -> implementation ID
+	PythonPackages map[string]string `json:"python_packages"` // name -> resource ID
+	Columns        map[string]string `json:"columns"`         // name -> resource ID of every upstream column
+	Aggregates     map[string]string `json:"aggregates"`      // name -> resource ID of every upstream aggregate
+	WorkloadIDs    []string          `json:"workload_ids"`    // the training workload and every upstream workload (set when the model is trained)
+	TrainedAt      *time.Time        `json:"trained_at"`      // set when the model is trained
+	ImageDigests   map[string]string `json:"image_digests"`   // image -> digest of each workload's images (saved by the operator once the training workload succeeds)
+}
+
+// NewModelManifest populates the parts of the model's manifest which are known before training (models must not be external)
+func (ctx *Context) NewModelManifest(model *Model) *ModelManifest {
+	manifest := &ModelManifest{
+		ModelID:        model.ID,
+		ModelName:      model.Name,
+		DatasetVersion: ctx.DatasetVersion,
+		DatasetID:      model.Dataset.ID,
+		TFRandomSeed:   model.Training.TfRandomSeed,
+		ImplID:         model.ImplID,
+		Transformers:   make(map[string]string),
+		Aggregators:    make(map[string]string),
+		PythonPackages: make(map[string]string),
+		Columns:        make(map[string]string),
+		Aggregates:     make(map[string]string),
+	}
+
+	dependencies := ctx.AllComputedResourceDependencies(model.ID)
+	for name, pythonPackage := range ctx.PythonPackages {
+		if dependencies.Has(pythonPackage.GetID()) {
+			manifest.PythonPackages[name] = pythonPackage.GetID()
+		}
+	}
+	for name, rawColumn := range ctx.RawColumns {
+		if dependencies.Has(rawColumn.GetID()) {
+			manifest.Columns[name] = rawColumn.GetID()
+		}
+	}
+	for name, aggregate := range ctx.Aggregates {
+		if dependencies.Has(aggregate.ID) {
+			manifest.Aggregates[name] = aggregate.ID
+			aggregator := ctx.Aggregators[aggregate.Aggregator]
+			manifest.Aggregators[aggregate.Aggregator] = aggregator.ImplID
+		}
+	}
+	for name, transformedColumn := range ctx.TransformedColumns {
+		if dependencies.Has(transformedColumn.ID) {
+			manifest.Columns[name] = transformedColumn.ID
+			transformer := ctx.Transformers[transformedColumn.Transformer]
+			manifest.Transformers[transformedColumn.Transformer] = transformer.ImplID
+		}
+	}
+
+	return manifest
+}
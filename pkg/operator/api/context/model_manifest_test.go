@@ -0,0 +1,106 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/operator/api/userconfig"
+)
+
+func computedResourceFields(id string) *ComputedResourceFields {
+	return &ComputedResourceFields{ResourceFields: &ResourceFields{ID: id, IDWithTags: id}}
+}
+
+func rawIntColumn(name string, id string) *RawIntColumn {
+	return &RawIntColumn{
+		RawIntColumn: &userconfig.RawIntColumn{
+			ResourceConfigFields: userconfig.ResourceConfigFields{Name: name},
+		},
+		ComputedResourceFields: computedResourceFields(id),
+	}
+}
+
+func TestNewModelManifest(t *testing.T) {
+	ctx := &Context{
+		DatasetVersion: "2019-03-08-09-58-35-701834",
+		PythonPackages: PythonPackages{
+			"pkg": &PythonPackage{ComputedResourceFields: computedResourceFields("pkg_id")},
+		},
+		RawColumns: RawColumns{
+			"age":    rawIntColumn("age", "age_id"),
+			"label":  rawIntColumn("label", "label_id"),
+			"unused": rawIntColumn("unused", "unused_id"),
+		},
+		Aggregators: Aggregators{
+			"cortex.mean": &Aggregator{ImplID: "mean_impl_id"},
+		},
+		Aggregates: Aggregates{
+			"age_mean": &Aggregate{
+				Aggregate: &userconfig.Aggregate{
+					Aggregator: "cortex.mean",
+					Inputs:     &userconfig.Inputs{Columns: map[string]interface{}{"col": "age"}},
+				},
+				ComputedResourceFields: computedResourceFields("age_mean_id"),
+			},
+		},
+		Transformers: Transformers{
+			"cortex.normalize": &Transformer{ImplID: "normalize_impl_id"},
+		},
+		TransformedColumns: TransformedColumns{
+			"age_normalized": &TransformedColumn{
+				TransformedColumn: &userconfig.TransformedColumn{
+					Transformer: "cortex.normalize",
+					Inputs: &userconfig.Inputs{
+						Columns: map[string]interface{}{"num": "age"},
+						Args:    map[string]interface{}{"mean": "age_mean"},
+					},
+				},
+				ComputedResourceFields: computedResourceFields("age_normalized_id"),
+			},
+		},
+	}
+
+	model := &Model{
+		Model: &userconfig.Model{
+			ResourceConfigFields: userconfig.ResourceConfigFields{Name: "dnn"},
+			FeatureColumns:       []string{"age_normalized"},
+			TargetColumn:         "label",
+			Training:             &userconfig.ModelTraining{TfRandomSeed: 1788},
+		},
+		ComputedResourceFields: computedResourceFields("dnn_id"),
+		ImplID:                 "dnn_impl_id",
+		Dataset: &TrainingDataset{
+			ComputedResourceFields: computedResourceFields("dataset_id"),
+		},
+	}
+	ctx.Models = Models{"dnn": model}
+
+	manifest := ctx.NewModelManifest(model)
+	require.Equal(t, "dnn_id", manifest.ModelID)
+	require.Equal(t, "dataset_id", manifest.DatasetID)
+	require.Equal(t, ctx.DatasetVersion, manifest.DatasetVersion)
+	require.Equal(t, int64(1788), manifest.TFRandomSeed)
+	require.Equal(t, "dnn_impl_id", manifest.ImplID)
+	require.Equal(t, map[string]string{"pkg": "pkg_id"}, manifest.PythonPackages)
+	require.Equal(t, map[string]string{"age": "age_id", "label": "label_id", "age_normalized": "age_normalized_id"}, manifest.Columns)
+	require.Equal(t, map[string]string{"age_mean": "age_mean_id"}, manifest.Aggregates)
+	require.Equal(t, map[string]string{"cortex.mean": "mean_impl_id"}, manifest.Aggregators)
+	require.Equal(t, map[string]string{"cortex.normalize": "normalize_impl_id"}, manifest.Transformers)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/operator/api/userconfig"
+)
+
+// ModelRun is appended to the model's run history each time the model is trained
+type ModelRun struct {
+	ModelID        string                 `json:"model_id"`
+	ModelName      string                 `json:"model_name"`
+	WorkloadID     string                 `json:"workload_id"`
+	DatasetVersion string                 `json:"dataset_version"`
+	Hparams        map[string]interface{} `json:"hparams"`
+	FeatureColumns []string               `json:"feature_columns"`
+	Compute        *userconfig.TFCompute  `json:"compute"`
+	Start          time.Time              `json:"start"`
+	End            time.Time              `json:"end"`
+	Metrics        map[string]float64     `json:"metrics"`
+}
+
+type ModelRuns []*ModelRun
+
+func (run *ModelRun) Duration() time.Duration {
+	return run.End.Sub(run.Start)
+}
+
+type MetricFilterOperator string
+
+const (
+	MetricFilterGreaterThanOrEqualTo MetricFilterOperator = ">="
+	MetricFilterLessThanOrEqualTo    MetricFilterOperator = "<="
+	MetricFilterNotEqualTo           MetricFilterOperator = "!="
+	MetricFilterGreaterThan          MetricFilterOperator = ">"
+	MetricFilterLessThan             MetricFilterOperator = "<"
+	MetricFilterEqualTo              MetricFilterOperator = "="
+)
+
+// Two-character operators are listed first so that they are matched before their one-character prefixes
+var MetricFilterOperators = []MetricFilterOperator{
+	MetricFilterGreaterThanOrEqualTo,
+	MetricFilterLessThanOrEqualTo,
+	MetricFilterNotEqualTo,
+	MetricFilterGreaterThan,
+	MetricFilterLessThan,
+	MetricFilterEqualTo,
+}
+
+// MetricFilter is a condition on one of a run's metrics (e.g. accuracy>=0.9)
+type MetricFilter struct {
+	Metric   string
+	Operator MetricFilterOperator
+	Value    float64
+}
+
+// Matches returns false if the run doesn't have the metric
+func (filter *MetricFilter) Matches(run *ModelRun) bool {
+	value, ok := run.Metrics[filter.Metric]
+	if !ok {
+		return false
+	}
+
+	switch filter.Operator {
+	case MetricFilterGreaterThanOrEqualTo:
+		return value >= filter.Value
+	case MetricFilterLessThanOrEqualTo:
+		return value <= filter.Value
+	case MetricFilterNotEqualTo:
+		return value != filter.Value
+	case MetricFilterGreaterThan:
+		return value > filter.Value
+	case MetricFilterLessThan:
+		return value < filter.Value
+	case MetricFilterEqualTo:
+		return value == filter.Value
+	}
+	return false
+}
+
+// Filter returns the runs which match all of the filters
+func (runs ModelRuns) Filter(filters ...*MetricFilter) ModelRuns {
+	filtered := ModelRuns{}
+	for _, run := range runs {
+		matchesAll := true
+		for _, filter := range filters {
+			if !filter.Matches(run) {
+				matchesAll = false
+				break
+			}
+		}
+		if matchesAll {
+			filtered = append(filtered, run)
+		}
+	}
+	return filtered
+}
+
+// SortByStart sorts the runs from oldest to newest
+func (runs ModelRuns) SortByStart() {
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].Start.Before(runs[j].Start)
+	})
+}
+
+// SortByMetric sorts the runs by the metric (runs without the metric are always last)
+func (runs ModelRuns) SortByMetric(metric string, descending bool) {
+	sort.SliceStable(runs, func(i, j int) bool {
+		valueI, okI := runs[i].Metrics[metric]
+		valueJ, okJ := runs[j].Metrics[metric]
+		if !okI || !okJ {
+			return okI && !okJ
+		}
+		if descending {
+			return valueI > valueJ
+		}
+		return valueI < valueJ
+	})
+}
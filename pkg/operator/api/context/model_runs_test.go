@@ -0,0 +1,65 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func modelRunIDs(runs ModelRuns) []string {
+	ids := make([]string, len(runs))
+	for i, run := range runs {
+		ids[i] = run.ModelID
+	}
+	return ids
+}
+
+func TestModelRuns(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := ModelRuns{
+		{ModelID: "b", Start: start.Add(2 * time.Hour), Metrics: map[string]float64{"accuracy": 0.9, "loss": 0.2}},
+		{ModelID: "a", Start: start, Metrics: map[string]float64{"accuracy": 0.8, "loss": 0.3}},
+		{ModelID: "c", Start: start.Add(time.Hour), Metrics: map[string]float64{"loss": 0.1}},
+	}
+
+	runs.SortByStart()
+	require.Equal(t, []string{"a", "c", "b"}, modelRunIDs(runs))
+
+	runs.SortByMetric("accuracy", true)
+	require.Equal(t, []string{"b", "a", "c"}, modelRunIDs(runs))
+
+	runs.SortByMetric("accuracy", false)
+	require.Equal(t, []string{"a", "b", "c"}, modelRunIDs(runs))
+
+	filtered := runs.Filter(&MetricFilter{Metric: "accuracy", Operator: MetricFilterGreaterThan, Value: 0.8})
+	require.Equal(t, []string{"b"}, modelRunIDs(filtered))
+
+	filtered = runs.Filter(&MetricFilter{Metric: "accuracy", Operator: MetricFilterGreaterThanOrEqualTo, Value: 0.8})
+	require.Equal(t, []string{"a", "b"}, modelRunIDs(filtered))
+
+	filtered = runs.Filter(
+		&MetricFilter{Metric: "loss", Operator: MetricFilterLessThan, Value: 0.25},
+		&MetricFilter{Metric: "loss", Operator: MetricFilterNotEqualTo, Value: 0.1},
+	)
+	require.Equal(t, []string{"b"}, modelRunIDs(filtered))
+
+	filtered = runs.Filter()
+	require.Len(t, filtered, 3)
+}
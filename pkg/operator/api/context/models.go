@@ -31,19 +31,40 @@ type TrainingDatasets map[string]*TrainingDataset
 type Model struct {
 	*userconfig.Model
 	*ComputedResourceFields
-	Key     string           `json:"key"`
-	ImplID  string           `json:"impl_id"`
-	ImplKey string           `json:"impl_key"`
-	Dataset *TrainingDataset `json:"dataset"`
+	Key             string           `json:"key"`
+	CheckpointKey   string           `json:"checkpoint_key"` // latest training checkpoint, used to resume retried training workloads
+	SummariesDir    string           `json:"summaries_dir"`  // TensorBoard event files (empty for external models)
+	ImplID          string           `json:"impl_id"`
+	ImplKey         string           `json:"impl_key"`
+	Dataset         *TrainingDataset `json:"dataset"`           // nil for external models
+	FoldMetricsKeys []string         `json:"fold_metrics_keys"` // one per fold for k-fold cross-validation
+	ImportanceKey   string           `json:"importance_key"`    // permutation importance of each feature column (if evaluation.feature_importance is enabled)
+	Lineage         *ModelLineage    `json:"lineage"`
+	LineageKeys     []string         `json:"lineage_keys"` // where Lineage is saved once the model is trained (by name and by ID)
+	RunsPrefix      string           `json:"runs_prefix"`  // where a ModelRun is saved each time the model is trained
+	Manifest        *ModelManifest   `json:"manifest"`
+	ManifestKey     string           `json:"manifest_key"` // where Manifest is saved once the model is trained
+}
+
+// ModelLineage is saved after a model is trained, so that later models can warm-start from it
+type ModelLineage struct {
+	ModelID                string `json:"model_id"`
+	ModelName              string `json:"model_name"`
+	BaseID                 string `json:"base_id"` // the model's ID excluding its warm start source
+	CheckpointKey          string `json:"checkpoint_key"`
+	WarmStartModelID       string `json:"warm_start_model_id"`
+	WarmStartCheckpointKey string `json:"warm_start_checkpoint_key"`
 }
 
 type TrainingDataset struct {
 	userconfig.ResourceConfigFields
 	*ComputedResourceFields
-	ModelName   string `json:"model_name"`
-	TrainKey    string `json:"train_key"`
-	EvalKey     string `json:"eval_key"`
-	MetadataKey string `json:"metadata_key"`
+	ModelName   string   `json:"model_name"`
+	Image       *string  `json:"image"` // the image of the model's transformed columns (nil for the default Spark image)
+	TrainKey    string   `json:"train_key"`
+	EvalKey     string   `json:"eval_key"`
+	FoldKeys    []string `json:"fold_keys"` // one per fold for k-fold cross-validation (instead of TrainKey and EvalKey)
+	MetadataKey string   `json:"metadata_key"`
 }
 
 func (trainingDataset *TrainingDataset) GetResourceType() resource.Type {
@@ -61,7 +82,7 @@ func (models Models) OneByID(id string) *Model {
 
 func (ctx *Context) OneTrainingDatasetByID(id string) *TrainingDataset {
 	for _, model := range ctx.Models {
-		if model.Dataset.ID == id {
+		if model.Dataset != nil && model.Dataset.ID == id {
 			return model.Dataset
 		}
 	}
@@ -71,7 +92,9 @@ func (ctx *Context) OneTrainingDatasetByID(id string) *TrainingDataset {
 func (models Models) GetTrainingDatasets() TrainingDatasets {
 	trainingDatasets := make(map[string]*TrainingDataset, len(models))
 	for _, model := range models {
-		trainingDatasets[model.Dataset.Name] = model.Dataset
+		if model.Dataset != nil {
+			trainingDatasets[model.Dataset.Name] = model.Dataset
+		}
 	}
 	return trainingDatasets
 }
@@ -88,9 +111,21 @@ func ValidateModelTargetType(targetType userconfig.ColumnType, modelType usercon
 			return userconfig.ErrorRegressionTargetType()
 		}
 		return nil
+	case userconfig.MultiLabelClassificationModelType:
+		if targetType != userconfig.IntegerListColumnType {
+			return userconfig.ErrorMultiLabelClassificationTargetType()
+		}
+		return nil
+	case userconfig.RankingModelType:
+		if targetType != userconfig.IntegerColumnType && targetType != userconfig.FloatColumnType {
+			return userconfig.ErrorRankingTargetType()
+		}
+		return nil
+	case userconfig.CustomModelType:
+		return nil
 	}
 
-	return configreader.ErrorInvalidStr(modelType.String(), "classification", "regression") // unexpected
+	return configreader.ErrorInvalidStr(modelType.String(), userconfig.ModelTypeStrings()...) // unexpected
 }
 
 func (ctx *Context) RawColumnInputNames(model *Model) []string {
@@ -0,0 +1,48 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/operator/api/userconfig"
+)
+
+func TestValidateModelTargetType(t *testing.T) {
+	require.NoError(t, ValidateModelTargetType(userconfig.IntegerColumnType, userconfig.ClassificationModelType))
+	require.Error(t, ValidateModelTargetType(userconfig.FloatColumnType, userconfig.ClassificationModelType))
+	require.Error(t, ValidateModelTargetType(userconfig.IntegerListColumnType, userconfig.ClassificationModelType))
+
+	require.NoError(t, ValidateModelTargetType(userconfig.IntegerColumnType, userconfig.RegressionModelType))
+	require.NoError(t, ValidateModelTargetType(userconfig.FloatColumnType, userconfig.RegressionModelType))
+	require.Error(t, ValidateModelTargetType(userconfig.StringColumnType, userconfig.RegressionModelType))
+
+	require.NoError(t, ValidateModelTargetType(userconfig.IntegerListColumnType, userconfig.MultiLabelClassificationModelType))
+	require.Error(t, ValidateModelTargetType(userconfig.IntegerColumnType, userconfig.MultiLabelClassificationModelType))
+	require.Error(t, ValidateModelTargetType(userconfig.FloatListColumnType, userconfig.MultiLabelClassificationModelType))
+
+	require.NoError(t, ValidateModelTargetType(userconfig.IntegerColumnType, userconfig.RankingModelType))
+	require.NoError(t, ValidateModelTargetType(userconfig.FloatColumnType, userconfig.RankingModelType))
+	require.Error(t, ValidateModelTargetType(userconfig.StringColumnType, userconfig.RankingModelType))
+
+	require.NoError(t, ValidateModelTargetType(userconfig.StringColumnType, userconfig.CustomModelType))
+	require.NoError(t, ValidateModelTargetType(userconfig.FloatListColumnType, userconfig.CustomModelType))
+
+	require.Error(t, ValidateModelTargetType(userconfig.IntegerColumnType, userconfig.UnknownModelType))
+}
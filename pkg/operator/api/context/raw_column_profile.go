@@ -0,0 +1,43 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+// RawColumnProfile summarizes the values of a raw column, it is computed when the raw dataset is ingested.
+// Min, Max, Mean, Stddev and Histogram are only set for numeric columns, and TopValues for string and bool columns.
+type RawColumnProfile struct {
+	Count         int64           `json:"count"`          // the number of rows, including nulls
+	NullCount     int64           `json:"null_count"`     // the number of null values
+	DistinctCount *int64          `json:"distinct_count"` // approximate (not computed for list columns)
+	Min           *float64        `json:"min"`
+	Max           *float64        `json:"max"`
+	Mean          *float64        `json:"mean"`
+	Stddev        *float64        `json:"stddev"`
+	TopValues     []*ValueCount   `json:"top_values"` // the most frequent non-null values, most frequent first
+	Histogram     []*HistogramBin `json:"histogram"`
+}
+
+type ValueCount struct {
+	Value interface{} `json:"value"`
+	Count int64       `json:"count"`
+}
+
+// HistogramBin counts the values in [Start, End), except for the last bin, which also includes End
+type HistogramBin struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Count int64   `json:"count"`
+}
@@ -27,6 +27,8 @@ type RawColumns map[string]RawColumn
 type RawColumn interface {
 	Column
 	GetCompute() *userconfig.SparkCompute
+	GetNullHandling() *userconfig.NullHandling
+	GetDataQuality() *userconfig.DataQuality
 	GetUserConfig() userconfig.Resource
 }
 
@@ -45,6 +47,36 @@ type RawStringColumn struct {
 	*ComputedResourceFields
 }
 
+type RawBoolColumn struct {
+	*userconfig.RawBoolColumn
+	*ComputedResourceFields
+}
+
+type RawTimestampColumn struct {
+	*userconfig.RawTimestampColumn
+	*ComputedResourceFields
+}
+
+type RawDateColumn struct {
+	*userconfig.RawDateColumn
+	*ComputedResourceFields
+}
+
+type RawIntListColumn struct {
+	*userconfig.RawIntListColumn
+	*ComputedResourceFields
+}
+
+type RawFloatListColumn struct {
+	*userconfig.RawFloatListColumn
+	*ComputedResourceFields
+}
+
+type RawStringListColumn struct {
+	*userconfig.RawStringListColumn
+	*ComputedResourceFields
+}
+
 func (rawColumns RawColumns) OneByID(id string) RawColumn {
 	for _, rawColumn := range rawColumns {
 		if rawColumn.GetID() == id {
@@ -98,3 +130,27 @@ func (rawColumn *RawFloatColumn) GetInputRawColumnNames() []string {
 func (rawColumn *RawStringColumn) GetInputRawColumnNames() []string {
 	return []string{rawColumn.GetName()}
 }
+
+func (rawColumn *RawBoolColumn) GetInputRawColumnNames() []string {
+	return []string{rawColumn.GetName()}
+}
+
+func (rawColumn *RawTimestampColumn) GetInputRawColumnNames() []string {
+	return []string{rawColumn.GetName()}
+}
+
+func (rawColumn *RawDateColumn) GetInputRawColumnNames() []string {
+	return []string{rawColumn.GetName()}
+}
+
+func (rawColumn *RawIntListColumn) GetInputRawColumnNames() []string {
+	return []string{rawColumn.GetName()}
+}
+
+func (rawColumn *RawFloatListColumn) GetInputRawColumnNames() []string {
+	return []string{rawColumn.GetName()}
+}
+
+func (rawColumn *RawStringListColumn) GetInputRawColumnNames() []string {
+	return []string{rawColumn.GetName()}
+}
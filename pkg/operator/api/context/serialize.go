@@ -25,14 +25,36 @@ import (
 )
 
 type RawColumnsTypeSplit struct {
-	RawIntColumns    map[string]*RawIntColumn    `json:"raw_int_columns"`
-	RawStringColumns map[string]*RawStringColumn `json:"raw_string_columns"`
-	RawFloatColumns  map[string]*RawFloatColumn  `json:"raw_float_columns"`
+	RawIntColumns        map[string]*RawIntColumn        `json:"raw_int_columns"`
+	RawStringColumns     map[string]*RawStringColumn     `json:"raw_string_columns"`
+	RawFloatColumns      map[string]*RawFloatColumn      `json:"raw_float_columns"`
+	RawBoolColumns       map[string]*RawBoolColumn       `json:"raw_bool_columns"`
+	RawTimestampColumns  map[string]*RawTimestampColumn  `json:"raw_timestamp_columns"`
+	RawDateColumns       map[string]*RawDateColumn       `json:"raw_date_columns"`
+	RawIntListColumns    map[string]*RawIntListColumn    `json:"raw_int_list_columns"`
+	RawFloatListColumns  map[string]*RawFloatListColumn  `json:"raw_float_list_columns"`
+	RawStringListColumns map[string]*RawStringListColumn `json:"raw_string_list_columns"`
 }
 
 type DataSplit struct {
 	CSVData     *userconfig.CSVData     `json:"csv_data"`
 	ParquetData *userconfig.ParquetData `json:"parquet_data"`
+	JSONData    *userconfig.JSONData    `json:"json_data"`
+	AvroData    *userconfig.AvroData    `json:"avro_data"`
+	JDBCData    *userconfig.JDBCData    `json:"jdbc_data"`
+	JoinData    *JoinDataSplit          `json:"join_data"`
+}
+
+type JoinDataSplit struct {
+	Type     userconfig.EnvironmentDataType `json:"type"`
+	Sources  []*DataSourceSplit             `json:"sources"`
+	Joins    []*userconfig.Join             `json:"joins"`
+	DropNull bool                           `json:"drop_null"`
+}
+
+type DataSourceSplit struct {
+	Name      string     `json:"name"`
+	DataSplit *DataSplit `json:"data"`
 }
 
 type Serial struct {
@@ -45,6 +67,12 @@ func (ctx Context) splitRawColumns() *RawColumnsTypeSplit {
 	var rawIntColumns = make(map[string]*RawIntColumn)
 	var rawFloatColumns = make(map[string]*RawFloatColumn)
 	var rawStringColumns = make(map[string]*RawStringColumn)
+	var rawBoolColumns = make(map[string]*RawBoolColumn)
+	var rawTimestampColumns = make(map[string]*RawTimestampColumn)
+	var rawDateColumns = make(map[string]*RawDateColumn)
+	var rawIntListColumns = make(map[string]*RawIntListColumn)
+	var rawFloatListColumns = make(map[string]*RawFloatListColumn)
+	var rawStringListColumns = make(map[string]*RawStringListColumn)
 	for name, rawColumn := range ctx.RawColumns {
 		switch typedRawColumn := rawColumn.(type) {
 		case *RawIntColumn:
@@ -53,13 +81,31 @@ func (ctx Context) splitRawColumns() *RawColumnsTypeSplit {
 			rawFloatColumns[name] = typedRawColumn
 		case *RawStringColumn:
 			rawStringColumns[name] = typedRawColumn
+		case *RawBoolColumn:
+			rawBoolColumns[name] = typedRawColumn
+		case *RawTimestampColumn:
+			rawTimestampColumns[name] = typedRawColumn
+		case *RawDateColumn:
+			rawDateColumns[name] = typedRawColumn
+		case *RawIntListColumn:
+			rawIntListColumns[name] = typedRawColumn
+		case *RawFloatListColumn:
+			rawFloatListColumns[name] = typedRawColumn
+		case *RawStringListColumn:
+			rawStringListColumns[name] = typedRawColumn
 		}
 	}
 
 	return &RawColumnsTypeSplit{
-		RawIntColumns:    rawIntColumns,
-		RawFloatColumns:  rawFloatColumns,
-		RawStringColumns: rawStringColumns,
+		RawIntColumns:        rawIntColumns,
+		RawFloatColumns:      rawFloatColumns,
+		RawStringColumns:     rawStringColumns,
+		RawBoolColumns:       rawBoolColumns,
+		RawTimestampColumns:  rawTimestampColumns,
+		RawDateColumns:       rawDateColumns,
+		RawIntListColumns:    rawIntListColumns,
+		RawFloatListColumns:  rawFloatListColumns,
+		RawStringListColumns: rawStringListColumns,
 	}
 }
 
@@ -75,33 +121,116 @@ func (serial Serial) collectRawColumns() RawColumns {
 	for name, rawColumn := range serial.RawColumnSplit.RawStringColumns {
 		rawColumns[name] = rawColumn
 	}
+	for name, rawColumn := range serial.RawColumnSplit.RawBoolColumns {
+		rawColumns[name] = rawColumn
+	}
+	for name, rawColumn := range serial.RawColumnSplit.RawTimestampColumns {
+		rawColumns[name] = rawColumn
+	}
+	for name, rawColumn := range serial.RawColumnSplit.RawDateColumns {
+		rawColumns[name] = rawColumn
+	}
+	for name, rawColumn := range serial.RawColumnSplit.RawIntListColumns {
+		rawColumns[name] = rawColumn
+	}
+	for name, rawColumn := range serial.RawColumnSplit.RawFloatListColumns {
+		rawColumns[name] = rawColumn
+	}
+	for name, rawColumn := range serial.RawColumnSplit.RawStringListColumns {
+		rawColumns[name] = rawColumn
+	}
 
 	return rawColumns
 }
 
 func (ctx Context) splitEnvironment() *DataSplit {
+	return splitData(ctx.Environment.Data)
+}
+
+func splitData(data userconfig.Data) *DataSplit {
 	var split DataSplit
-	switch typedData := ctx.Environment.Data.(type) {
+	switch typedData := data.(type) {
 	case *userconfig.CSVData:
 		split.CSVData = typedData
 	case *userconfig.ParquetData:
 		split.ParquetData = typedData
+	case *userconfig.JSONData:
+		split.JSONData = typedData
+	case *userconfig.AvroData:
+		split.AvroData = typedData
+	case *userconfig.JDBCData:
+		split.JDBCData = typedData
+	case *userconfig.JoinData:
+		sources := make([]*DataSourceSplit, len(typedData.Sources))
+		for i, source := range typedData.Sources {
+			sources[i] = &DataSourceSplit{
+				Name:      source.Name,
+				DataSplit: splitData(source.Data),
+			}
+		}
+		split.JoinData = &JoinDataSplit{
+			Type:     typedData.Type,
+			Sources:  sources,
+			Joins:    typedData.Joins,
+			DropNull: typedData.DropNull,
+		}
 	}
 
 	return &split
 }
 
 func (serial *Serial) collectEnvironment() (*Environment, error) {
-	if serial.DataSplit.ParquetData != nil && serial.DataSplit.CSVData == nil {
-		serial.Environment.Data = serial.DataSplit.ParquetData
-	} else if serial.DataSplit.CSVData != nil && serial.DataSplit.ParquetData == nil {
-		serial.Environment.Data = serial.DataSplit.CSVData
-	} else {
-		return nil, errors.Wrap(userconfig.ErrorSpecifyOnlyOne("CSV", "PARQUET"), serial.App.Name, resource.EnvironmentType.String(), userconfig.DataKey)
+	data, err := collectData(serial.DataSplit)
+	if err != nil {
+		return nil, errors.Wrap(err, serial.App.Name, resource.EnvironmentType.String(), userconfig.DataKey)
 	}
+	serial.Environment.Data = data
 	return serial.Environment, nil
 }
 
+func collectData(split *DataSplit) (userconfig.Data, error) {
+	var datas []userconfig.Data
+	if split.CSVData != nil {
+		datas = append(datas, split.CSVData)
+	}
+	if split.ParquetData != nil {
+		datas = append(datas, split.ParquetData)
+	}
+	if split.JSONData != nil {
+		datas = append(datas, split.JSONData)
+	}
+	if split.AvroData != nil {
+		datas = append(datas, split.AvroData)
+	}
+	if split.JDBCData != nil {
+		datas = append(datas, split.JDBCData)
+	}
+	if split.JoinData != nil {
+		joinData := &userconfig.JoinData{
+			Type:     split.JoinData.Type,
+			Sources:  make(userconfig.DataSources, len(split.JoinData.Sources)),
+			Joins:    split.JoinData.Joins,
+			DropNull: split.JoinData.DropNull,
+		}
+		for i, source := range split.JoinData.Sources {
+			sourceData, err := collectData(source.DataSplit)
+			if err != nil {
+				return nil, errors.Wrap(err, userconfig.SourcesKey, source.Name)
+			}
+			joinData.Sources[i] = &userconfig.DataSource{
+				Name: source.Name,
+				Data: sourceData,
+			}
+		}
+		datas = append(datas, joinData)
+	}
+
+	if len(datas) != 1 {
+		return nil, userconfig.ErrorSpecifyOnlyOne("CSV", "PARQUET", "JSON", "AVRO", "JDBC", "JOIN")
+	}
+	return datas[0], nil
+}
+
 func (ctx Context) ToSerial() *Serial {
 	serial := Serial{
 		Context:        ctx,
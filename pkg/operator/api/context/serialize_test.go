@@ -0,0 +1,125 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/operator/api/userconfig"
+)
+
+func TestJoinDataMsgpackRoundTrip(t *testing.T) {
+	ctx := Context{
+		App: &App{App: &userconfig.App{Name: "app"}},
+		Environment: &Environment{
+			Environment: &userconfig.Environment{
+				Data: &userconfig.JoinData{
+					Type: userconfig.JoinEnvironmentDataType,
+					Sources: userconfig.DataSources{
+						{
+							Name: "users",
+							Data: &userconfig.JDBCData{
+								Type:   userconfig.JDBCEnvironmentDataType,
+								URL:    "jdbc:postgresql://localhost/db",
+								Table:  pointer.String("users"),
+								Schema: []*userconfig.JDBCColumn{{JDBCColumnName: "id", RawColumnName: "user_id"}},
+							},
+						},
+						{
+							Name: "events",
+							Data: &userconfig.ParquetData{
+								Type:   userconfig.ParquetEnvironmentDataType,
+								Path:   "s3a://bucket/events.parquet",
+								Schema: []*userconfig.ParquetColumn{{ParquetColumnName: "user", RawColumnName: "user_id"}},
+							},
+						},
+					},
+					Joins: []*userconfig.Join{{Source: "events", Keys: []string{"user_id"}, Type: userconfig.LeftJoinType}},
+				},
+			},
+		},
+	}
+
+	msgpackBytes, err := ctx.ToMsgpackBytes()
+	require.NoError(t, err)
+	deserialized, err := FromMsgpackBytes(msgpackBytes)
+	require.NoError(t, err)
+
+	joinData, ok := deserialized.Environment.Data.(*userconfig.JoinData)
+	require.True(t, ok)
+	require.Equal(t, []string{"users", "events"}, joinData.Sources.Names())
+	require.Equal(t, "jdbc:postgresql://localhost/db", joinData.Sources[0].Data.(*userconfig.JDBCData).URL)
+	require.Equal(t, "s3a://bucket/events.parquet", joinData.Sources[1].Data.GetExternalPath())
+	require.Equal(t, userconfig.LeftJoinType, joinData.Joins[0].Type)
+	require.Equal(t, []string{"user_id"}, joinData.GetIngestedColumns())
+}
+
+func TestRawColumnsMsgpackRoundTrip(t *testing.T) {
+	fillNullAction := userconfig.FillNullAction
+	ctx := Context{
+		App: &App{App: &userconfig.App{Name: "app"}},
+		Environment: &Environment{
+			Environment: &userconfig.Environment{
+				Data: &userconfig.CSVData{Type: userconfig.CSVEnvironmentDataType, Path: "s3a://bucket/data.csv"},
+			},
+		},
+		RawColumns: RawColumns{
+			"is_active": &RawBoolColumn{
+				RawBoolColumn: &userconfig.RawBoolColumn{
+					ResourceConfigFields: userconfig.ResourceConfigFields{Name: "is_active"},
+					Type:                 userconfig.BoolColumnType,
+				},
+				ComputedResourceFields: &ComputedResourceFields{ResourceFields: &ResourceFields{}},
+			},
+			"created_at": &RawTimestampColumn{
+				RawTimestampColumn: &userconfig.RawTimestampColumn{
+					ResourceConfigFields: userconfig.ResourceConfigFields{Name: "created_at"},
+					Type:                 userconfig.TimestampColumnType,
+				},
+				ComputedResourceFields: &ComputedResourceFields{ResourceFields: &ResourceFields{}},
+			},
+			"birth_date": &RawDateColumn{
+				RawDateColumn: &userconfig.RawDateColumn{
+					ResourceConfigFields: userconfig.ResourceConfigFields{Name: "birth_date"},
+					NullHandling:         userconfig.NullHandling{OnNull: &fillNullAction, FillValue: "2019-03-01"},
+					Type:                 userconfig.DateColumnType,
+					Required:             true,
+				},
+				ComputedResourceFields: &ComputedResourceFields{ResourceFields: &ResourceFields{}},
+			},
+		},
+	}
+
+	msgpackBytes, err := ctx.ToMsgpackBytes()
+	require.NoError(t, err)
+	deserialized, err := FromMsgpackBytes(msgpackBytes)
+	require.NoError(t, err)
+
+	require.Len(t, deserialized.RawColumns, 3)
+	require.Equal(t, userconfig.BoolColumnType, deserialized.RawColumns["is_active"].GetType())
+	require.Equal(t, userconfig.TimestampColumnType, deserialized.RawColumns["created_at"].GetType())
+	birthDate, ok := deserialized.RawColumns["birth_date"].(*RawDateColumn)
+	require.True(t, ok)
+	require.True(t, birthDate.Required)
+	require.Equal(t, userconfig.FillNullAction, *birthDate.OnNull)
+	require.Equal(t, "2019-03-01", birthDate.FillValue)
+	require.Nil(t, birthDate.FillAggregate)
+	require.False(t, deserialized.RawColumns["is_active"].GetNullHandling().IsSet())
+}
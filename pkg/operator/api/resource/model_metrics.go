@@ -0,0 +1,90 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"sort"
+)
+
+type CrossValidationMetrics struct {
+	Folds    []map[string]float64 `json:"folds"`
+	Mean     map[string]float64   `json:"mean"`
+	Variance map[string]float64   `json:"variance"`
+}
+
+// NewCrossValidationMetrics summarizes the evaluation metrics of each fold (only metrics reported by every fold are summarized)
+func NewCrossValidationMetrics(folds []map[string]float64) *CrossValidationMetrics {
+	metrics := &CrossValidationMetrics{
+		Folds:    folds,
+		Mean:     make(map[string]float64),
+		Variance: make(map[string]float64),
+	}
+	if len(folds) == 0 {
+		return metrics
+	}
+
+	for name := range folds[0] {
+		values := make([]float64, 0, len(folds))
+		for _, fold := range folds {
+			if value, ok := fold[name]; ok {
+				values = append(values, value)
+			}
+		}
+		if len(values) != len(folds) {
+			continue
+		}
+
+		var sum float64
+		for _, value := range values {
+			sum += value
+		}
+		mean := sum / float64(len(values))
+
+		var sumSquares float64
+		for _, value := range values {
+			sumSquares += (value - mean) * (value - mean)
+		}
+
+		metrics.Mean[name] = mean
+		metrics.Variance[name] = sumSquares / float64(len(values))
+	}
+
+	return metrics
+}
+
+// FeatureImportance is saved after training if the model's evaluation.feature_importance is enabled
+type FeatureImportance struct {
+	Importance map[string]float64     `json:"importance"` // the increase in evaluation loss when each feature column is shuffled
+	Baselines  map[string]interface{} `json:"baselines"`  // the value each feature column is replaced with to explain predictions
+}
+
+// Ranked returns the feature columns from most to least important
+func (featureImportance *FeatureImportance) Ranked() []string {
+	columnNames := make([]string, 0, len(featureImportance.Importance))
+	for columnName := range featureImportance.Importance {
+		columnNames = append(columnNames, columnName)
+	}
+	sort.Slice(columnNames, func(i, j int) bool {
+		iImportance := featureImportance.Importance[columnNames[i]]
+		jImportance := featureImportance.Importance[columnNames[j]]
+		if iImportance == jImportance {
+			return columnNames[i] < columnNames[j]
+		}
+		return iImportance > jImportance
+	})
+	return columnNames
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCrossValidationMetrics(t *testing.T) {
+	metrics := NewCrossValidationMetrics([]map[string]float64{
+		{"accuracy": 0.8, "loss": 1.0},
+		{"accuracy": 0.9, "loss": 3.0},
+		{"accuracy": 1.0},
+	})
+	require.Len(t, metrics.Folds, 3)
+	require.InDelta(t, 0.9, metrics.Mean["accuracy"], 1e-9)
+	require.InDelta(t, 0.02/3, metrics.Variance["accuracy"], 1e-9)
+
+	// loss isn't reported by every fold
+	_, ok := metrics.Mean["loss"]
+	require.False(t, ok)
+
+	metrics = NewCrossValidationMetrics(nil)
+	require.Empty(t, metrics.Mean)
+	require.Empty(t, metrics.Variance)
+}
+
+func TestFeatureImportanceRanked(t *testing.T) {
+	featureImportance := &FeatureImportance{
+		Importance: map[string]float64{
+			"petal_length": 0.4,
+			"sepal_width":  -0.01,
+			"petal_width":  0.4,
+			"sepal_length": 0.05,
+		},
+	}
+	require.Equal(t, []string{"petal_length", "petal_width", "sepal_length", "sepal_width"}, featureImportance.Ranked())
+
+	require.Empty(t, (&FeatureImportance{}).Ranked())
+}
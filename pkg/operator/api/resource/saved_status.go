@@ -33,7 +33,9 @@ type BaseSavedStatus struct {
 
 type DataSavedStatus struct {
 	BaseSavedStatus
-	ExitCode DataExitCode `json:"exit_code"`
+	ExitCode    DataExitCode `json:"exit_code"`
+	Attempt     int          `json:"attempt"`      // only set for retryable workloads
+	MaxAttempts int          `json:"max_attempts"` // only set for retryable workloads
 }
 
 type APISavedStatus struct {
@@ -48,6 +50,7 @@ const (
 	ExitCodeDataFailed    DataExitCode = "failed"
 	ExitCodeDataKilled    DataExitCode = "killed"
 	ExitCodeDataOOM       DataExitCode = "oom"
+	ExitCodeDataTimedOut  DataExitCode = "timed_out"
 )
 
 func DataSavedStatusPtrsEqual(savedStatus *DataSavedStatus, savedStatus2 *DataSavedStatus) bool {
@@ -77,6 +80,9 @@ func (savedStatus *DataSavedStatus) Equal(savedStatus2 DataSavedStatus) bool {
 	if savedStatus.ExitCode != savedStatus2.ExitCode {
 		return false
 	}
+	if savedStatus.Attempt != savedStatus2.Attempt || savedStatus.MaxAttempts != savedStatus2.MaxAttempts {
+		return false
+	}
 	return true
 }
 
@@ -17,6 +17,7 @@ limitations under the License.
 package resource
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -97,6 +98,7 @@ const (
 	StatusSucceeded
 	StatusFailed
 	StatusKilled
+	StatusTimedOut
 
 	// API statuses
 	StatusUpdating
@@ -125,6 +127,7 @@ var statusCodes = []string{
 	"status_succeeded",
 	"status_failed",
 	"status_killed",
+	"status_timed_out",
 
 	"status_updating",
 	"status_ready",
@@ -153,6 +156,7 @@ var statusCodeMessages = []string{
 	"ready",      // StatusDataSucceeded
 	"error",      // StatusDataFailed
 	"terminated", // StatusDataKilled
+	"timed out",  // StatusDataTimedOut
 
 	"updating", // 	StatusAPIUpdating
 	"ready",    // StatusAPIReady
@@ -191,6 +195,7 @@ var statusSortBuckets = []int{
 	0, // StatusSucceeded
 	1, // StatusFailed
 	1, // StatusKilled
+	1, // StatusTimedOut
 
 	3, // StatusUpdating
 	0, // StatusReady
@@ -237,6 +242,9 @@ func (status *DataStatus) Message() string {
 		case TrainingDatasetType:
 			return TrainingDatasetRunningMessage
 		case ModelType:
+			if status.Attempt > 1 {
+				return fmt.Sprintf("%s (attempt %d/%d)", ModelRunningMessage, status.Attempt, status.MaxAttempts)
+			}
 			return ModelRunningMessage
 		}
 	}
@@ -34,13 +34,40 @@ type ErrorResponse struct {
 }
 
 type GetResourcesResponse struct {
-	Context          *context.Context                    `json:"context"`
-	DataStatuses     map[string]*resource.DataStatus     `json:"data_statuses"`
-	APIStatuses      map[string]*resource.APIStatus      `json:"api_statuses"`
-	APIGroupStatuses map[string]*resource.APIGroupStatus `json:"api_name_statuses"`
-	APIsBaseURL      string                              `json:"apis_base_url"`
+	Context           *context.Context                     `json:"context"`
+	DataStatuses      map[string]*resource.DataStatus      `json:"data_statuses"`
+	APIStatuses       map[string]*resource.APIStatus       `json:"api_statuses"`
+	APIGroupStatuses  map[string]*resource.APIGroupStatus  `json:"api_name_statuses"`
+	APIsBaseURL       string                               `json:"apis_base_url"`
+	ScheduleStatuses  []*resource.ScheduleStatus           `json:"schedule_statuses"`
+	RawColumnProfiles map[string]*context.RawColumnProfile `json:"raw_column_profiles"`
 }
 
 type GetAggregateResponse struct {
 	Value []byte `json:"value"`
 }
+
+type GetRawColumnQualityReportResponse struct {
+	Report *context.DataQualityReport `json:"report"`
+}
+
+type GetModelMetricsResponse struct {
+	CrossValidation   *resource.CrossValidationMetrics `json:"cross_validation"`
+	FeatureImportance *resource.FeatureImportance      `json:"feature_importance"`
+}
+
+type GetModelManifestResponse struct {
+	Manifest *context.ModelManifest `json:"manifest"`
+}
+
+type GetModelRunsResponse struct {
+	Runs context.ModelRuns `json:"runs"`
+}
+
+type StartTensorBoardResponse struct {
+	URL string `json:"url"`
+}
+
+type StopTensorBoardResponse struct {
+	Message string `json:"message"`
+}
@@ -27,6 +27,7 @@ type API struct {
 	ResourceConfigFields
 	ModelName string      `json:"model_name" yaml:"model_name"`
 	Compute   *APICompute `json:"compute" yaml:"compute"`
+	Image     *string     `json:"image" yaml:"image"`
 	Tags      Tags        `json:"tags" yaml:"tags"`
 }
 
@@ -48,6 +49,7 @@ var apiValidation = &cr.StructValidation{
 			},
 		},
 		apiComputeFieldValidation,
+		imageFieldValidation,
 		tagsFieldValidation,
 		typeFieldValidation,
 	},
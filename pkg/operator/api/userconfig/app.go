@@ -21,7 +21,8 @@ import (
 )
 
 type App struct {
-	Name string `json:"name" yaml:"name"`
+	Name     string  `json:"name" yaml:"name"`
+	Schedule *string `json:"schedule" yaml:"schedule"`
 }
 
 var appValidation = &cr.StructValidation{
@@ -34,6 +35,12 @@ var appValidation = &cr.StructValidation{
 				AlphaNumericDashUnderscore: true,
 			},
 		},
+		{
+			StructField: "Schedule",
+			StringPtrValidation: &cr.StringPtrValidation{
+				Validator: ValidateSchedule,
+			},
+		},
 		typeFieldValidation,
 	},
 }
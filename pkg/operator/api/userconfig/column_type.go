@@ -18,6 +18,9 @@ package userconfig
 
 import (
 	"strings"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/cast"
 )
 
 type ColumnType int
@@ -31,6 +34,9 @@ const (
 	IntegerListColumnType
 	FloatListColumnType
 	StringListColumnType
+	BoolColumnType
+	TimestampColumnType
+	DateColumnType
 )
 
 var columnTypes = []string{
@@ -41,6 +47,9 @@ var columnTypes = []string{
 	"INT_LIST_COLUMN",
 	"FLOAT_LIST_COLUMN",
 	"STRING_LIST_COLUMN",
+	"BOOL_COLUMN",
+	"TIMESTAMP_COLUMN",
+	"DATE_COLUMN",
 }
 
 var columnJSONPlaceholders = []string{
@@ -51,6 +60,9 @@ var columnJSONPlaceholders = []string{
 	"[INT]",
 	"[FLOAT]",
 	"[\"STRING\"]",
+	"BOOL",
+	"\"TIMESTAMP\"",
+	"\"DATE\"",
 }
 
 func ColumnTypeFromString(s string) ColumnType {
@@ -70,6 +82,96 @@ func (t ColumnType) String() string {
 	return columnTypes[t]
 }
 
+func (t ColumnType) IsList() bool {
+	return t == IntegerListColumnType || t == FloatListColumnType || t == StringListColumnType
+}
+
+func ScalarColumnTypeStrings() []string {
+	var scalarTypes []string
+	for _, columnTypeStr := range ColumnTypeStrings() {
+		if !ColumnTypeFromString(columnTypeStr).IsList() {
+			scalarTypes = append(scalarTypes, columnTypeStr)
+		}
+	}
+	return scalarTypes
+}
+
+var listElementTypes = map[ColumnType]ColumnType{
+	IntegerListColumnType: IntegerColumnType,
+	FloatListColumnType:   FloatColumnType,
+	StringListColumnType:  StringColumnType,
+}
+
+// castColumnValue casts a config value to the type of a column's values (dates and timestamps remain strings)
+func castColumnValue(value interface{}, columnType ColumnType) (interface{}, bool) {
+	if elementType, ok := listElementTypes[columnType]; ok {
+		values, ok := cast.InterfaceToInterfaceSlice(value)
+		if !ok {
+			return nil, false
+		}
+		castedValues := make([]interface{}, len(values))
+		for i, value := range values {
+			castedValue, ok := castColumnValue(value, elementType)
+			if !ok {
+				return nil, false
+			}
+			castedValues[i] = castedValue
+		}
+		return castedValues, true
+	}
+
+	switch columnType {
+	case IntegerColumnType:
+		return cast.InterfaceToInt64(value)
+	case FloatColumnType:
+		return cast.InterfaceToFloat64(value)
+	case StringColumnType, TimestampColumnType:
+		str, ok := value.(string)
+		return str, ok
+	case BoolColumnType:
+		boolean, ok := value.(bool)
+		return boolean, ok
+	case DateColumnType:
+		str, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		if _, err := time.Parse(FilterDateFormat, str); err != nil {
+			return nil, false
+		}
+		return str, true
+	}
+	return nil, false
+}
+
+func columnValueTypeStr(columnType ColumnType, isList bool) string {
+	if elementType, ok := listElementTypes[columnType]; ok {
+		if isList {
+			return "lists of " + columnValueTypeStr(elementType, true)
+		}
+		return "a list of " + columnValueTypeStr(elementType, true)
+	}
+
+	var singular, plural string
+	switch columnType {
+	case IntegerColumnType:
+		singular, plural = "an integer", "integers"
+	case FloatColumnType:
+		singular, plural = "a number", "numbers"
+	case BoolColumnType:
+		singular, plural = "a boolean", "booleans"
+	case DateColumnType:
+		singular, plural = "a date ("+FilterDateFormat+")", "dates ("+FilterDateFormat+")"
+	default:
+		singular, plural = "a string", "strings"
+	}
+
+	if isList {
+		return plural
+	}
+	return singular
+}
+
 func (t ColumnType) JSONPlaceholder() string {
 	return columnJSONPlaceholders[t]
 }
@@ -43,6 +43,7 @@ type SparkCompute struct {
 	ExecutorMem         Quantity  `json:"executor_mem" yaml:"executor_mem"`
 	ExecutorMemOverhead *Quantity `json:"executor_mem_overhead" yaml:"executor_mem_overhead"`
 	MemOverheadFactor   *float64  `json:"mem_overhead_factor" yaml:"mem_overhead_factor"`
+	MaxDuration         *string   `json:"max_duration" yaml:"max_duration"`
 }
 
 var sparkComputeStructValidation = &cr.StructValidation{
@@ -117,6 +118,12 @@ var sparkComputeStructValidation = &cr.StructValidation{
 				LessThan:             pointer.Float64(1),
 			},
 		},
+		{
+			StructField: "MaxDuration",
+			StringPtrValidation: &cr.StringPtrValidation{
+				Validator: ValidateDuration,
+			},
+		},
 	},
 }
 
@@ -290,6 +297,17 @@ func MaxSparkCompute(sparkComputes ...*SparkCompute) *SparkCompute {
 		}
 	}
 
+	// The job is only limited if every resource in it is limited, in which case it may run for the longest of their limits
+	for i, sparkCompute := range sparkComputes {
+		if sparkCompute.MaxDuration == nil {
+			aggregated.MaxDuration = nil
+			break
+		}
+		if i == 0 || *DurationSecs(sparkCompute.MaxDuration) > *DurationSecs(aggregated.MaxDuration) {
+			aggregated.MaxDuration = sparkCompute.MaxDuration
+		}
+	}
+
 	return &aggregated
 }
 
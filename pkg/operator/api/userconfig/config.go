@@ -52,6 +52,12 @@ var typeFieldValidation = &cr.StructFieldValidation{
 	Nil: true,
 }
 
+// imageFieldValidation validates the image override of a resource (the operator checks it against its registry allowlist)
+var imageFieldValidation = &cr.StructFieldValidation{
+	StructField:         "Image",
+	StringPtrValidation: &cr.StringPtrValidation{},
+}
+
 func mergeConfigs(target *Config, source *Config) error {
 	target.Environments = append(target.Environments, source.Environments...)
 	target.RawColumns = append(target.RawColumns, source.RawColumns...)
@@ -151,26 +157,25 @@ func (config *Config) Validate(envName string) error {
 	}
 
 	// Check ingested columns match raw columns
-	rawColumnNames := config.RawColumns.Names()
 	for _, env := range config.Environments {
-		ingestedColumnNames := env.Data.GetIngestedColumns()
-		missingColumns := slices.SubtractStrSlice(rawColumnNames, ingestedColumnNames)
-		if len(missingColumns) > 0 {
-			return errors.Wrap(ErrorRawColumnNotInEnv(env.Name), Identify(config.RawColumns.Get(missingColumns[0])))
-		}
-		extraColumns := slices.SubtractStrSlice(rawColumnNames, ingestedColumnNames)
-		if len(extraColumns) > 0 {
-			return errors.Wrap(ErrorUndefinedResource(extraColumns[0], resource.RawColumnType), Identify(env), DataKey, SchemaKey)
+		if err := env.ValidateRawColumns(config.RawColumns); err != nil {
+			return err
 		}
 	}
 
 	// Check model columns exist
 	columnNames := config.ColumnNames()
 	for _, model := range config.Models {
-		if !slices.HasString(columnNames, model.TargetColumn) {
+		if model.TargetColumn != "" && !slices.HasString(columnNames, model.TargetColumn) {
 			return errors.Wrap(ErrorUndefinedResource(model.TargetColumn, resource.RawColumnType, resource.TransformedColumnType),
 				Identify(model), TargetColumnKey)
 		}
+		for i, target := range model.Targets {
+			if !slices.HasString(columnNames, target.Column) {
+				return errors.Wrap(ErrorUndefinedResource(target.Column, resource.RawColumnType, resource.TransformedColumnType),
+					Identify(model), TargetsKey, s.Index(i), ColumnKey)
+			}
+		}
 		missingColumnNames := slices.SubtractStrSlice(model.FeatureColumns, columnNames)
 		if len(missingColumnNames) > 0 {
 			return errors.Wrap(ErrorUndefinedResource(missingColumnNames[0], resource.RawColumnType, resource.TransformedColumnType),
@@ -189,6 +194,19 @@ func (config *Config) Validate(envName string) error {
 			return errors.Wrap(ErrorUndefinedResource(missingTrainingColumnNames[0], resource.RawColumnType, resource.TransformedColumnType),
 				Identify(model), TrainingColumnsKey)
 		}
+
+		// check weight column (the types of transformed columns are checked once their transformers are known)
+		if model.WeightColumn != "" {
+			if !slices.HasString(columnNames, model.WeightColumn) {
+				return errors.Wrap(ErrorUndefinedResource(model.WeightColumn, resource.RawColumnType, resource.TransformedColumnType),
+					Identify(model), WeightColumnKey)
+			}
+			if rawColumn := config.RawColumns.Get(model.WeightColumn); rawColumn != nil {
+				if columnType := rawColumn.GetType(); columnType != IntegerColumnType && columnType != FloatColumnType {
+					return errors.Wrap(ErrorWeightColumnType(model.WeightColumn, columnType), Identify(model), WeightColumnKey)
+				}
+			}
+		}
 	}
 
 	// Check api models exist
@@ -26,6 +26,8 @@ const (
 	FeatureColumnsKey  = "feature_columns"
 	TrainingColumnsKey = "training_columns"
 	TargetColumnKey    = "target_column"
+	TargetsKey         = "targets"
+	ColumnKey          = "column"
 	AggregatesKey      = "aggregates"
 	ModelNameKey       = "model_name"
 	InputsKey          = "inputs"
@@ -36,6 +38,7 @@ const (
 	PathKey            = "path"
 	ValueKey           = "value"
 	YAMLKey            = "yaml"
+	ScheduleKey        = "schedule"
 
 	// environment
 	LimitKey          = "limit"
@@ -43,13 +46,47 @@ const (
 	FractionOfRowsKey = "fraction_of_rows"
 	RandomizeKey      = "randomize"
 	RandomSeedKey     = "random_seed"
+	StratifyByKey     = "stratify_by"
+	SampleByKeyKey    = "sample_by_key"
+	URLKey            = "url"
+	TableKey          = "table"
+	QueryKey          = "query"
+	PartitionColKey   = "partition_column"
+	LowerBoundKey     = "lower_bound"
+	UpperBoundKey     = "upper_bound"
+	NumPartitionsKey  = "num_partitions"
+	SourcesKey        = "sources"
+	JoinsKey          = "joins"
+	KeysKey           = "keys"
+	FilterKey         = "filter"
+
+	// raw column
+	OnNullKey          = "on_null"
+	FillValueKey       = "fill_value"
+	FillAggregateKey   = "fill_aggregate"
+	PatternKey         = "pattern"
+	MinLengthKey       = "min_length"
+	MaxLengthKey       = "max_length"
+	MaxNullFractionKey = "max_null_fraction"
+	UniqueKey          = "unique"
+	ErrorThresholdKey  = "error_threshold"
 
 	// model
 	NumEpochsKey           = "num_epochs"
 	NumStepsKey            = "num_steps"
+	StrategyKey            = "strategy"
+	FoldsKey               = "folds"
 	SaveCheckpointSecsKey  = "save_checkpoints_secs"
 	SaveCheckpointStepsKey = "save_checkpoints_steps"
 	DataPartitionRatioKey  = "data_partition_ratio"
 	TrainingKey            = "training"
 	EvaluationKey          = "evaluation"
+	ExternalModelKey       = "external_model"
+	WarmStartKey           = "warm_start"
+	MaxDurationKey         = "max_duration"
+	FrameworkKey           = "framework"
+	ImageKey               = "image"
+	WeightColumnKey        = "weight_column"
+	ClassWeightKey         = "class_weight"
+	FeatureImportanceKey   = "feature_importance"
 )
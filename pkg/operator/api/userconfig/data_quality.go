@@ -0,0 +1,65 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+import (
+	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+)
+
+// DataQuality describes dataset-level checks on a raw column, and how many violations are tolerated during ingestion
+type DataQuality struct {
+	MaxNullFraction *float64 `json:"max_null_fraction" yaml:"max_null_fraction"`
+	Unique          bool     `json:"unique" yaml:"unique"`
+	ErrorThreshold  float64  `json:"error_threshold" yaml:"error_threshold"`
+}
+
+var dataQualityFieldValidations = []*cr.StructFieldValidation{
+	{
+		Key:         MaxNullFractionKey,
+		StructField: "MaxNullFraction",
+		Float64PtrValidation: &cr.Float64PtrValidation{
+			GreaterThanOrEqualTo: pointer.Float64(0),
+			LessThanOrEqualTo:    pointer.Float64(1),
+		},
+	},
+	{
+		Key:         UniqueKey,
+		StructField: "Unique",
+		BoolValidation: &cr.BoolValidation{
+			Default: false,
+		},
+	},
+	{
+		Key:         ErrorThresholdKey,
+		StructField: "ErrorThreshold",
+		Float64Validation: &cr.Float64Validation{
+			Default:              0,
+			GreaterThanOrEqualTo: pointer.Float64(0),
+			LessThanOrEqualTo:    pointer.Float64(1),
+		},
+	},
+}
+
+func (dataQuality *DataQuality) GetDataQuality() *DataQuality {
+	return dataQuality
+}
+
+// IsSet is false when none of the data quality settings are configured (the default)
+func (dataQuality *DataQuality) IsSet() bool {
+	return dataQuality.MaxNullFraction != nil || dataQuality.Unique || dataQuality.ErrorThreshold != 0
+}
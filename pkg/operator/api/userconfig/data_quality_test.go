@@ -0,0 +1,46 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+)
+
+func TestRawStringColumnValidate(t *testing.T) {
+	column := &RawStringColumn{}
+	require.NoError(t, column.Validate())
+	require.False(t, column.GetDataQuality().IsSet())
+
+	column = &RawStringColumn{Pattern: pointer.String("[a-z]+@[a-z]+\\.com")}
+	require.NoError(t, column.Validate())
+
+	column = &RawStringColumn{Pattern: pointer.String("[a-z")}
+	require.Error(t, column.Validate())
+
+	column = &RawStringColumn{MinLength: pointer.Int64(2), MaxLength: pointer.Int64(2)}
+	require.NoError(t, column.Validate())
+
+	column = &RawStringColumn{MinLength: pointer.Int64(3), MaxLength: pointer.Int64(2)}
+	require.Error(t, column.Validate())
+
+	column = &RawStringColumn{DataQuality: DataQuality{Unique: true}}
+	require.True(t, column.GetDataQuality().IsSet())
+}
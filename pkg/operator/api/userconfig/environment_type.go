@@ -22,12 +22,20 @@ const (
 	UnknownEnvironmentDataType EnvironmentDataType = iota
 	CSVEnvironmentDataType
 	ParquetEnvironmentDataType
+	JSONEnvironmentDataType
+	AvroEnvironmentDataType
+	JDBCEnvironmentDataType
+	JoinEnvironmentDataType
 )
 
 var environmentDataTypes = []string{
 	"unknown",
 	"csv",
 	"parquet",
+	"json",
+	"avro",
+	"jdbc",
+	"join",
 }
 
 func EnvironmentDataTypeFromString(s string) EnvironmentDataType {
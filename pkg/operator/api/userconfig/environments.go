@@ -21,7 +21,9 @@ import (
 	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	"github.com/cortexlabs/cortex/pkg/lib/slices"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
 	"github.com/cortexlabs/cortex/pkg/operator/api/resource"
 )
 
@@ -31,6 +33,7 @@ type Environment struct {
 	ResourceConfigFields
 	LogLevel *LogLevel `json:"log_level" yaml:"log_level"`
 	Limit    *Limit    `json:"limit" yaml:"limit"`
+	Filter   Filter    `json:"filter" yaml:"filter"`
 	Data     Data      `json:"-" yaml:"-"`
 }
 
@@ -51,6 +54,12 @@ var environmentValidation = &cr.StructValidation{
 			StructField:      "Limit",
 			StructValidation: limitValidation,
 		},
+		{
+			StructField: "Filter",
+			StructListValidation: &cr.StructListValidation{
+				StructValidation: filterConditionValidation,
+			},
+		},
 		{
 			StructField:               "Data",
 			Key:                       "data",
@@ -65,6 +74,8 @@ type Limit struct {
 	FractionOfRows *float32 `json:"fraction_of_rows" yaml:"fraction_of_rows"`
 	Randomize      *bool    `json:"randomize" yaml:"randomize"`
 	RandomSeed     *int64   `json:"random_seed" yaml:"random_seed"`
+	StratifyBy     *string  `json:"stratify_by" yaml:"stratify_by"`
+	SampleByKey    *string  `json:"sample_by_key" yaml:"sample_by_key"`
 }
 
 var limitValidation = &cr.StructValidation{
@@ -90,6 +101,14 @@ var limitValidation = &cr.StructValidation{
 			StructField:        "RandomSeed",
 			Int64PtrValidation: &cr.Int64PtrValidation{},
 		},
+		{
+			StructField:         "StratifyBy",
+			StringPtrValidation: &cr.StringPtrValidation{},
+		},
+		{
+			StructField:         "SampleByKey",
+			StringPtrValidation: &cr.StringPtrValidation{},
+		},
 	},
 }
 
@@ -124,23 +143,60 @@ type Data interface {
 }
 
 var dataValidation = &cr.InterfaceStructValidation{
-	TypeKey:         "type",
-	TypeStructField: "Type",
-	ParsedInterfaceStructTypes: map[interface{}]*cr.InterfaceStructType{
-		CSVEnvironmentDataType: {
-			Type:                   (*CSVData)(nil),
-			StructFieldValidations: csvDataFieldValidations,
-		},
-		ParquetEnvironmentDataType: {
-			Type:                   (*ParquetData)(nil),
-			StructFieldValidations: parquetDataFieldValidations,
-		},
+	TypeKey:                    "type",
+	TypeStructField:            "Type",
+	ParsedInterfaceStructTypes: dataStructTypes(),
+	Parser: func(str string) (interface{}, error) {
+		return EnvironmentDataTypeFromString(str), nil
 	},
+}
+
+// sourceDataValidation is used for the sources of a join (joins can't be nested)
+var sourceDataValidation = &cr.InterfaceStructValidation{
+	TypeKey:                    "type",
+	TypeStructField:            "Type",
+	ParsedInterfaceStructTypes: sourceDataStructTypes,
 	Parser: func(str string) (interface{}, error) {
 		return EnvironmentDataTypeFromString(str), nil
 	},
 }
 
+var sourceDataStructTypes = map[interface{}]*cr.InterfaceStructType{
+	CSVEnvironmentDataType: {
+		Type:                   (*CSVData)(nil),
+		StructFieldValidations: csvDataFieldValidations,
+	},
+	ParquetEnvironmentDataType: {
+		Type:                   (*ParquetData)(nil),
+		StructFieldValidations: parquetDataFieldValidations,
+	},
+	JSONEnvironmentDataType: {
+		Type:                   (*JSONData)(nil),
+		StructFieldValidations: jsonDataFieldValidations,
+	},
+	AvroEnvironmentDataType: {
+		Type:                   (*AvroData)(nil),
+		StructFieldValidations: avroDataFieldValidations,
+	},
+	JDBCEnvironmentDataType: {
+		Type:                   (*JDBCData)(nil),
+		StructFieldValidations: jdbcDataFieldValidations,
+	},
+}
+
+func dataStructTypes() map[interface{}]*cr.InterfaceStructType {
+	structTypes := map[interface{}]*cr.InterfaceStructType{
+		JoinEnvironmentDataType: {
+			Type:                   (*JoinData)(nil),
+			StructFieldValidations: joinDataFieldValidations,
+		},
+	}
+	for dataType, structType := range sourceDataStructTypes {
+		structTypes[dataType] = structType
+	}
+	return structTypes
+}
+
 type CSVData struct {
 	Type      EnvironmentDataType `json:"type" yaml:"type"`
 	Path      string              `json:"path" yaml:"path"`
@@ -168,6 +224,8 @@ type CSVConfig struct {
 	Multiline                 *bool   `json:"multiline" yaml:"multiline"`
 	CharToEscapeQuoteEscaping *string `json:"char_to_escape_quote_escaping" yaml:"char_to_escape_quote_escaping"`
 	EmptyValue                *string `json:"empty_value" yaml:"empty_value"`
+	DateFormat                *string `json:"date_format" yaml:"date_format"`
+	TimestampFormat           *string `json:"timestamp_format" yaml:"timestamp_format"`
 }
 
 var csvDataFieldValidations = []*cr.StructFieldValidation{
@@ -265,6 +323,14 @@ var csvDataFieldValidations = []*cr.StructFieldValidation{
 					StructField:         "EmptyValue",
 					StringPtrValidation: &cr.StringPtrValidation{},
 				},
+				{
+					StructField:         "DateFormat",
+					StringPtrValidation: &cr.StringPtrValidation{},
+				},
+				{
+					StructField:         "TimestampFormat",
+					StringPtrValidation: &cr.StringPtrValidation{},
+				},
 			},
 		},
 	},
@@ -320,6 +386,305 @@ var parquetColumnValidation = &cr.StructValidation{
 	},
 }
 
+// JSONData is newline-delimited JSON (JSON Lines)
+type JSONData struct {
+	Type     EnvironmentDataType `json:"type" yaml:"type"`
+	Path     string              `json:"path" yaml:"path"`
+	Schema   []*JSONColumn       `json:"schema" yaml:"schema"`
+	DropNull bool                `json:"drop_null" yaml:"drop_null"`
+}
+
+var jsonDataFieldValidations = []*cr.StructFieldValidation{
+	{
+		StructField: "Path",
+		StringValidation: cr.GetS3aPathValidation(&cr.S3aPathValidation{
+			Required: true,
+		}),
+	},
+	{
+		StructField: "Schema",
+		StructListValidation: &cr.StructListValidation{
+			StructValidation: jsonColumnValidation,
+		},
+	},
+	{
+		StructField: "DropNull",
+		BoolValidation: &cr.BoolValidation{
+			Default: false,
+		},
+	},
+}
+
+// JSONColumn maps a (possibly nested) field to a raw column, nested fields are separated by "." (e.g. "user.address.city")
+type JSONColumn struct {
+	JSONFieldPath string `json:"json_field_path" yaml:"json_field_path"`
+	RawColumnName string `json:"raw_column_name" yaml:"raw_column_name"`
+}
+
+var jsonColumnValidation = &cr.StructValidation{
+	StructFieldValidations: []*cr.StructFieldValidation{
+		{
+			StructField: "JSONFieldPath",
+			StringValidation: &cr.StringValidation{
+				Required: true,
+			},
+		},
+		{
+			StructField: "RawColumnName",
+			StringValidation: &cr.StringValidation{
+				Required: true,
+			},
+		},
+	},
+}
+
+type AvroData struct {
+	Type     EnvironmentDataType `json:"type" yaml:"type"`
+	Path     string              `json:"path" yaml:"path"`
+	Schema   []*AvroColumn       `json:"schema" yaml:"schema"`
+	DropNull bool                `json:"drop_null" yaml:"drop_null"`
+}
+
+var avroDataFieldValidations = []*cr.StructFieldValidation{
+	{
+		StructField: "Path",
+		StringValidation: cr.GetS3aPathValidation(&cr.S3aPathValidation{
+			Required: true,
+		}),
+	},
+	{
+		StructField: "Schema",
+		StructListValidation: &cr.StructListValidation{
+			StructValidation: avroColumnValidation,
+		},
+	},
+	{
+		StructField: "DropNull",
+		BoolValidation: &cr.BoolValidation{
+			Default: false,
+		},
+	},
+}
+
+// AvroColumn maps a (possibly nested) field to a raw column, nested fields are separated by "." (e.g. "user.address.city")
+type AvroColumn struct {
+	AvroFieldPath string `json:"avro_field_path" yaml:"avro_field_path"`
+	RawColumnName string `json:"raw_column_name" yaml:"raw_column_name"`
+}
+
+var avroColumnValidation = &cr.StructValidation{
+	StructFieldValidations: []*cr.StructFieldValidation{
+		{
+			StructField: "AvroFieldPath",
+			StringValidation: &cr.StringValidation{
+				Required: true,
+			},
+		},
+		{
+			StructField: "RawColumnName",
+			StringValidation: &cr.StringValidation{
+				Required: true,
+			},
+		},
+	},
+}
+
+// JDBCData reads a table or the result of a query from a database (e.g. Postgres)
+type JDBCData struct {
+	Type              EnvironmentDataType `json:"type" yaml:"type"`
+	URL               string              `json:"url" yaml:"url"`
+	Table             *string             `json:"table" yaml:"table"`
+	Query             *string             `json:"query" yaml:"query"`
+	PartitionColumn   *string             `json:"partition_column" yaml:"partition_column"`
+	LowerBound        *int64              `json:"lower_bound" yaml:"lower_bound"`
+	UpperBound        *int64              `json:"upper_bound" yaml:"upper_bound"`
+	NumPartitions     *int64              `json:"num_partitions" yaml:"num_partitions"`
+	CredentialsSecret *string             `json:"credentials_secret" yaml:"credentials_secret"`
+	Schema            []*JDBCColumn       `json:"schema" yaml:"schema"`
+	DropNull          bool                `json:"drop_null" yaml:"drop_null"`
+}
+
+var jdbcDataFieldValidations = []*cr.StructFieldValidation{
+	{
+		StructField: "URL",
+		StringValidation: &cr.StringValidation{
+			Required: true,
+			Prefix:   "jdbc:",
+		},
+	},
+	{
+		StructField:         "Table",
+		StringPtrValidation: &cr.StringPtrValidation{},
+	},
+	{
+		StructField:         "Query",
+		StringPtrValidation: &cr.StringPtrValidation{},
+	},
+	{
+		StructField:         "PartitionColumn",
+		StringPtrValidation: &cr.StringPtrValidation{},
+	},
+	{
+		StructField:        "LowerBound",
+		Int64PtrValidation: &cr.Int64PtrValidation{},
+	},
+	{
+		StructField:        "UpperBound",
+		Int64PtrValidation: &cr.Int64PtrValidation{},
+	},
+	{
+		StructField: "NumPartitions",
+		Int64PtrValidation: &cr.Int64PtrValidation{
+			GreaterThan: pointer.Int64(0),
+		},
+	},
+	{
+		StructField: "CredentialsSecret",
+		StringPtrValidation: &cr.StringPtrValidation{
+			AlphaNumericDashDotUnderscore: true,
+		},
+	},
+	{
+		StructField: "Schema",
+		StructListValidation: &cr.StructListValidation{
+			StructValidation: jdbcColumnValidation,
+		},
+	},
+	{
+		StructField: "DropNull",
+		BoolValidation: &cr.BoolValidation{
+			Default: false,
+		},
+	},
+}
+
+type JDBCColumn struct {
+	JDBCColumnName string `json:"jdbc_column_name" yaml:"jdbc_column_name"`
+	RawColumnName  string `json:"raw_column_name" yaml:"raw_column_name"`
+}
+
+var jdbcColumnValidation = &cr.StructValidation{
+	StructFieldValidations: []*cr.StructFieldValidation{
+		{
+			StructField: "JDBCColumnName",
+			StringValidation: &cr.StringValidation{
+				Required: true,
+			},
+		},
+		{
+			StructField: "RawColumnName",
+			StringValidation: &cr.StringValidation{
+				Required: true,
+			},
+		},
+	},
+}
+
+// JoinData combines multiple named sources: the first source is joined with each of the others in the order of Joins
+type JoinData struct {
+	Type     EnvironmentDataType `json:"type" yaml:"type"`
+	Sources  DataSources         `json:"sources" yaml:"sources"`
+	Joins    []*Join             `json:"joins" yaml:"joins"`
+	DropNull bool                `json:"drop_null" yaml:"drop_null"`
+}
+
+var joinDataFieldValidations = []*cr.StructFieldValidation{
+	{
+		StructField: "Sources",
+		StructListValidation: &cr.StructListValidation{
+			Required:         true,
+			StructValidation: dataSourceValidation,
+		},
+	},
+	{
+		StructField: "Joins",
+		StructListValidation: &cr.StructListValidation{
+			StructValidation: joinValidation,
+		},
+	},
+	{
+		StructField: "DropNull",
+		BoolValidation: &cr.BoolValidation{
+			Default: false,
+		},
+	},
+}
+
+type DataSources []*DataSource
+
+type DataSource struct {
+	Name string `json:"name" yaml:"name"`
+	Data Data   `json:"-" yaml:"-"`
+}
+
+var dataSourceValidation = &cr.StructValidation{
+	StructFieldValidations: []*cr.StructFieldValidation{
+		{
+			StructField: "Name",
+			StringValidation: &cr.StringValidation{
+				Required:                   true,
+				AlphaNumericDashUnderscore: true,
+			},
+		},
+		{
+			StructField:               "Data",
+			Key:                       "data",
+			InterfaceStructValidation: sourceDataValidation,
+		},
+	},
+}
+
+// Join joins Source onto the sources which precede it, matching rows on the raw columns in Keys
+type Join struct {
+	Source string   `json:"source" yaml:"source"`
+	Keys   []string `json:"keys" yaml:"keys"`
+	Type   JoinType `json:"type" yaml:"type"`
+}
+
+var joinValidation = &cr.StructValidation{
+	StructFieldValidations: []*cr.StructFieldValidation{
+		{
+			StructField: "Source",
+			StringValidation: &cr.StringValidation{
+				Required: true,
+			},
+		},
+		{
+			StructField: "Keys",
+			StringListValidation: &cr.StringListValidation{
+				Required: true,
+			},
+		},
+		{
+			StructField: "Type",
+			StringValidation: &cr.StringValidation{
+				Default:       InnerJoinType.String(),
+				AllowedValues: JoinTypeStrings(),
+			},
+			Parser: func(str string) (interface{}, error) {
+				return JoinTypeFromString(str), nil
+			},
+		},
+	},
+}
+
+func (sources DataSources) Get(name string) *DataSource {
+	for _, source := range sources {
+		if source.Name == name {
+			return source
+		}
+	}
+	return nil
+}
+
+func (sources DataSources) Names() []string {
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name
+	}
+	return names
+}
+
 func (environments Environments) Validate() error {
 	for _, env := range environments {
 		if err := env.Validate(); err != nil {
@@ -352,8 +717,17 @@ func (env *Environment) Validate() error {
 		if env.Limit.Randomize != nil && env.Limit.NumRows == nil && env.Limit.FractionOfRows == nil {
 			return errors.Wrap(ErrorOneOfPrerequisitesNotDefined(RandomizeKey, LimitKey, FractionOfRowsKey), Identify(env))
 		}
-		if env.Limit.RandomSeed != nil && env.Limit.Randomize == nil {
-			return errors.Wrap(ErrorOneOfPrerequisitesNotDefined(RandomSeedKey, RandomizeKey), Identify(env))
+		if env.Limit.RandomSeed != nil && env.Limit.Randomize == nil && env.Limit.StratifyBy == nil && env.Limit.SampleByKey == nil {
+			return errors.Wrap(ErrorOneOfPrerequisitesNotDefined(RandomSeedKey, RandomizeKey, StratifyByKey, SampleByKeyKey), Identify(env))
+		}
+		if env.Limit.StratifyBy != nil && env.Limit.SampleByKey != nil {
+			return errors.Wrap(ErrorSpecifyOnlyOne(StratifyByKey, SampleByKeyKey), Identify(env), LimitKey)
+		}
+		if env.Limit.StratifyBy != nil && env.Limit.NumRows == nil && env.Limit.FractionOfRows == nil {
+			return errors.Wrap(ErrorOneOfPrerequisitesNotDefined(StratifyByKey, NumRowsKey, FractionOfRowsKey), Identify(env), LimitKey)
+		}
+		if env.Limit.SampleByKey != nil && env.Limit.NumRows == nil && env.Limit.FractionOfRows == nil {
+			return errors.Wrap(ErrorOneOfPrerequisitesNotDefined(SampleByKeyKey, NumRowsKey, FractionOfRowsKey), Identify(env), LimitKey)
 		}
 	}
 
@@ -365,6 +739,60 @@ func (env *Environment) Validate() error {
 	return nil
 }
 
+// ValidateRawColumns checks that the environment ingests every raw column, and that the columns it references are raw columns
+func (env *Environment) ValidateRawColumns(rawColumns RawColumns) error {
+	rawColumnNames := rawColumns.Names()
+	ingestedColumnNames := env.Data.GetIngestedColumns()
+	missingColumns := slices.SubtractStrSlice(rawColumnNames, ingestedColumnNames)
+	if len(missingColumns) > 0 {
+		return errors.Wrap(ErrorRawColumnNotInEnv(env.Name), Identify(rawColumns.Get(missingColumns[0])))
+	}
+	// join keys are read from each source, which only keeps raw columns
+	if joinData, ok := env.Data.(*JoinData); ok {
+		for i, join := range joinData.Joins {
+			for _, key := range join.Keys {
+				if !slices.HasString(rawColumnNames, key) {
+					return errors.Wrap(ErrorUndefinedResource(key, resource.RawColumnType), Identify(env), DataKey, JoinsKey, s.Index(i), KeysKey)
+				}
+			}
+		}
+	}
+	// CSV schemas name every column in the file, so their columns which aren't raw columns are ignored
+	extraColumns := slices.SubtractStrSlice(slices.SubtractStrSlice(ingestedColumnNames, rawColumnNames), csvIngestedColumns(env.Data))
+	if len(extraColumns) > 0 {
+		return errors.Wrap(ErrorUndefinedResource(extraColumns[0], resource.RawColumnType), Identify(env), DataKey, SchemaKey)
+	}
+	for _, columnName := range csvIngestedColumns(env.Data) {
+		if rawColumn := rawColumns.Get(columnName); rawColumn != nil && rawColumn.GetType().IsList() {
+			return errors.Wrap(ErrorListColumnInCSV(columnName, rawColumn.GetType()), Identify(env), DataKey, SchemaKey)
+		}
+	}
+	if env.Limit != nil && env.Limit.StratifyBy != nil {
+		rawColumn := rawColumns.Get(*env.Limit.StratifyBy)
+		if rawColumn == nil {
+			return errors.Wrap(ErrorUndefinedResource(*env.Limit.StratifyBy, resource.RawColumnType), Identify(env), LimitKey, StratifyByKey)
+		}
+		if rawColumn.GetType() != IntegerColumnType && rawColumn.GetType() != StringColumnType && rawColumn.GetType() != BoolColumnType {
+			return errors.Wrap(ErrorUnsupportedColumnType(rawColumn.GetType().String(), []string{IntegerColumnType.String(), StringColumnType.String(), BoolColumnType.String()}), Identify(env), LimitKey, StratifyByKey)
+		}
+	}
+	if env.Limit != nil && env.Limit.SampleByKey != nil {
+		if rawColumns.Get(*env.Limit.SampleByKey) == nil {
+			return errors.Wrap(ErrorUndefinedResource(*env.Limit.SampleByKey, resource.RawColumnType), Identify(env), LimitKey, SampleByKeyKey)
+		}
+	}
+	for i, condition := range env.Filter {
+		rawColumn := rawColumns.Get(condition.Column)
+		if rawColumn == nil {
+			return errors.Wrap(ErrorUndefinedResource(condition.Column, resource.RawColumnType), Identify(env), FilterKey, s.Index(i), ColumnKey)
+		}
+		if err := condition.Validate(rawColumn.GetType()); err != nil {
+			return errors.Wrap(err, Identify(env), FilterKey, s.Index(i))
+		}
+	}
+	return nil
+}
+
 func (csvData *CSVData) Validate() error {
 	return nil
 }
@@ -373,6 +801,102 @@ func (parqData *ParquetData) Validate() error {
 	return nil
 }
 
+func (jsonData *JSONData) Validate() error {
+	return nil
+}
+
+func (avroData *AvroData) Validate() error {
+	return nil
+}
+
+func (jdbcData *JDBCData) Validate() error {
+	if (jdbcData.Table == nil) == (jdbcData.Query == nil) {
+		return errors.Wrap(ErrorSpecifyOnlyOne(TableKey, QueryKey), DataKey)
+	}
+
+	partitionKeys := []string{PartitionColKey, LowerBoundKey, UpperBoundKey, NumPartitionsKey}
+	numPartitionFields := 0
+	for _, isSet := range []bool{jdbcData.PartitionColumn != nil, jdbcData.LowerBound != nil, jdbcData.UpperBound != nil, jdbcData.NumPartitions != nil} {
+		if isSet {
+			numPartitionFields++
+		}
+	}
+	if numPartitionFields != 0 && numPartitionFields != len(partitionKeys) {
+		return errors.Wrap(ErrorSpecifyAllOrNone(partitionKeys...), DataKey)
+	}
+	if jdbcData.LowerBound != nil && *jdbcData.LowerBound >= *jdbcData.UpperBound {
+		return errors.Wrap(cr.ErrorMustBeLessThan(*jdbcData.LowerBound, *jdbcData.UpperBound), DataKey, LowerBoundKey)
+	}
+
+	return nil
+}
+
+func (joinData *JoinData) Validate() error {
+	if len(joinData.Sources) == 0 {
+		return errors.Wrap(cr.ErrorCannotBeEmpty(), DataKey, SourcesKey)
+	}
+
+	if dups := slices.FindDuplicateStrs(joinData.Sources.Names()); len(dups) > 0 {
+		return errors.Wrap(configreader.ErrorDuplicatedValue(dups[0]), DataKey, SourcesKey, NameKey)
+	}
+
+	for _, source := range joinData.Sources {
+		if err := source.Data.Validate(); err != nil {
+			return errors.Wrap(err, DataKey, SourcesKey, source.Name)
+		}
+		if dups := slices.FindDuplicateStrs(source.Data.GetIngestedColumns()); len(dups) > 0 {
+			return errors.Wrap(configreader.ErrorDuplicatedValue(dups[0]), DataKey, SourcesKey, source.Name, DataKey, SchemaKey, "column name")
+		}
+	}
+
+	joinedSources := strset.New(joinData.Sources[0].Name)
+	joinedColumns := strset.New(joinData.Sources[0].Data.GetIngestedColumns()...)
+	joinKeys := strset.New()
+	for i, join := range joinData.Joins {
+		source := joinData.Sources.Get(join.Source)
+		if source == nil {
+			return errors.Wrap(ErrorUndefinedDataSource(join.Source), DataKey, JoinsKey, s.Index(i), SourcesKey)
+		}
+		if joinedSources.Has(join.Source) {
+			return errors.Wrap(ErrorDataSourceJoinedTwice(join.Source), DataKey, JoinsKey, s.Index(i))
+		}
+
+		sourceColumns := strset.New(source.Data.GetIngestedColumns()...)
+		for _, key := range join.Keys {
+			if !sourceColumns.Has(key) || !joinedColumns.Has(key) {
+				return errors.Wrap(ErrorJoinKeyNotInSources(key, join.Source), DataKey, JoinsKey, s.Index(i), KeysKey)
+			}
+		}
+
+		joinedSources.Add(join.Source)
+		joinedColumns.Merge(sourceColumns)
+		joinKeys.Add(join.Keys...)
+	}
+
+	for _, sourceName := range joinData.Sources.Names() {
+		if !joinedSources.Has(sourceName) {
+			return errors.Wrap(ErrorDataSourceNotJoined(sourceName), DataKey, JoinsKey)
+		}
+	}
+
+	// Each raw column (other than join keys) must come from exactly one source
+	columnSources := make(map[string][]string)
+	for _, source := range joinData.Sources {
+		for _, columnName := range source.Data.GetIngestedColumns() {
+			if !joinKeys.Has(columnName) {
+				columnSources[columnName] = append(columnSources[columnName], source.Name)
+			}
+		}
+	}
+	for _, columnName := range joinData.GetIngestedColumns() {
+		if len(columnSources[columnName]) > 1 {
+			return errors.Wrap(ErrorColumnInMultipleSources(columnName, columnSources[columnName]), DataKey, SourcesKey)
+		}
+	}
+
+	return nil
+}
+
 func (csvData *CSVData) GetExternalPath() string {
 	return csvData.Path
 }
@@ -381,6 +905,35 @@ func (parqData *ParquetData) GetExternalPath() string {
 	return parqData.Path
 }
 
+func (jsonData *JSONData) GetExternalPath() string {
+	return jsonData.Path
+}
+
+func (avroData *AvroData) GetExternalPath() string {
+	return avroData.Path
+}
+
+// GetExternalPath returns an empty string since joined data may come from multiple paths (see GetExternalPaths)
+func (joinData *JoinData) GetExternalPath() string {
+	return ""
+}
+
+// GetExternalPaths returns the S3 paths of all sources which are read from S3
+func (joinData *JoinData) GetExternalPaths() []string {
+	var paths []string
+	for _, source := range joinData.Sources {
+		if path := source.Data.GetExternalPath(); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// GetExternalPath returns an empty string since JDBC data is not read from S3
+func (jdbcData *JDBCData) GetExternalPath() string {
+	return ""
+}
+
 func (csvData *CSVData) GetIngestedColumns() []string {
 	return csvData.Schema
 }
@@ -393,6 +946,54 @@ func (parqData *ParquetData) GetIngestedColumns() []string {
 	return columnNames
 }
 
+func (jsonData *JSONData) GetIngestedColumns() []string {
+	columnNames := make([]string, len(jsonData.Schema))
+	for i, jsonCol := range jsonData.Schema {
+		columnNames[i] = jsonCol.RawColumnName
+	}
+	return columnNames
+}
+
+func (avroData *AvroData) GetIngestedColumns() []string {
+	columnNames := make([]string, len(avroData.Schema))
+	for i, avroCol := range avroData.Schema {
+		columnNames[i] = avroCol.RawColumnName
+	}
+	return columnNames
+}
+
+func (jdbcData *JDBCData) GetIngestedColumns() []string {
+	columnNames := make([]string, len(jdbcData.Schema))
+	for i, jdbcCol := range jdbcData.Schema {
+		columnNames[i] = jdbcCol.RawColumnName
+	}
+	return columnNames
+}
+
+// GetIngestedColumns returns the union of the sources' columns (join keys are included once)
+func (joinData *JoinData) GetIngestedColumns() []string {
+	var columnNames []string
+	for _, source := range joinData.Sources {
+		columnNames = append(columnNames, source.Data.GetIngestedColumns()...)
+	}
+	return slices.UniqueStrings(columnNames)
+}
+
+// csvIngestedColumns returns the columns which are read from CSV files (including CSV sources of joins)
+func csvIngestedColumns(data Data) []string {
+	switch typedData := data.(type) {
+	case *CSVData:
+		return typedData.Schema
+	case *JoinData:
+		var columnNames []string
+		for _, source := range typedData.Sources {
+			columnNames = append(columnNames, csvIngestedColumns(source.Data)...)
+		}
+		return columnNames
+	}
+	return nil
+}
+
 func (env *Environment) GetResourceType() resource.Type {
 	return resource.EnvironmentType
 }
@@ -0,0 +1,227 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+)
+
+func TestJDBCDataValidate(t *testing.T) {
+	jdbcData := &JDBCData{URL: "jdbc:postgresql://localhost/db", Table: pointer.String("events")}
+	require.NoError(t, jdbcData.Validate())
+
+	jdbcData = &JDBCData{URL: "jdbc:postgresql://localhost/db", Query: pointer.String("SELECT * FROM events")}
+	require.NoError(t, jdbcData.Validate())
+
+	jdbcData = &JDBCData{URL: "jdbc:postgresql://localhost/db"}
+	require.Error(t, jdbcData.Validate())
+
+	jdbcData = &JDBCData{URL: "jdbc:postgresql://localhost/db", Table: pointer.String("events"), Query: pointer.String("SELECT * FROM events")}
+	require.Error(t, jdbcData.Validate())
+
+	jdbcData = &JDBCData{
+		URL:             "jdbc:postgresql://localhost/db",
+		Table:           pointer.String("events"),
+		PartitionColumn: pointer.String("id"),
+		LowerBound:      pointer.Int64(0),
+		UpperBound:      pointer.Int64(1000),
+		NumPartitions:   pointer.Int64(4),
+	}
+	require.NoError(t, jdbcData.Validate())
+
+	jdbcData.NumPartitions = nil
+	require.Error(t, jdbcData.Validate())
+
+	jdbcData.NumPartitions = pointer.Int64(4)
+	jdbcData.LowerBound = pointer.Int64(1000)
+	require.Error(t, jdbcData.Validate())
+}
+
+func parquetSource(name string, rawColumnNames ...string) *DataSource {
+	schema := make([]*ParquetColumn, len(rawColumnNames))
+	for i, rawColumnName := range rawColumnNames {
+		schema[i] = &ParquetColumn{ParquetColumnName: rawColumnName, RawColumnName: rawColumnName}
+	}
+	return &DataSource{
+		Name: name,
+		Data: &ParquetData{Path: "s3a://bucket/" + name, Schema: schema},
+	}
+}
+
+func TestJoinDataValidate(t *testing.T) {
+	joinData := &JoinData{
+		Sources: DataSources{
+			parquetSource("users", "user_id", "age"),
+			parquetSource("events", "user_id", "duration"),
+		},
+		Joins: []*Join{{Source: "events", Keys: []string{"user_id"}, Type: InnerJoinType}},
+	}
+	require.NoError(t, joinData.Validate())
+	require.ElementsMatch(t, []string{"user_id", "age", "duration"}, joinData.GetIngestedColumns())
+	require.ElementsMatch(t, []string{"s3a://bucket/users", "s3a://bucket/events"}, joinData.GetExternalPaths())
+
+	// column mapped in multiple sources
+	joinData.Sources[1] = parquetSource("events", "user_id", "age")
+	require.Error(t, joinData.Validate())
+
+	// join key missing from the joined source
+	joinData.Sources[1] = parquetSource("events", "id", "duration")
+	require.Error(t, joinData.Validate())
+
+	// source not joined
+	joinData.Sources[1] = parquetSource("events", "user_id", "duration")
+	joinData.Joins = nil
+	require.Error(t, joinData.Validate())
+
+	// undefined source
+	joinData.Joins = []*Join{{Source: "sessions", Keys: []string{"user_id"}}}
+	require.Error(t, joinData.Validate())
+
+	// first source joined
+	joinData.Joins = []*Join{{Source: "users", Keys: []string{"user_id"}}}
+	require.Error(t, joinData.Validate())
+}
+
+func TestFilterConditionValidate(t *testing.T) {
+	condition := &FilterCondition{Column: "age", Op: GreaterThanOrEqualFilterOp, Value: 18}
+	require.NoError(t, condition.Validate(IntegerColumnType))
+	require.Equal(t, int64(18), condition.Value)
+
+	condition = &FilterCondition{Column: "age", Op: GreaterThanOrEqualFilterOp, Value: 18}
+	require.NoError(t, condition.Validate(FloatColumnType))
+	require.Equal(t, float64(18), condition.Value)
+
+	condition = &FilterCondition{Column: "age", Op: LessThanFilterOp, Value: "18"}
+	require.Error(t, condition.Validate(IntegerColumnType))
+
+	condition = &FilterCondition{Column: "country", Op: InFilterOp, Value: []interface{}{"US", "CA"}}
+	require.NoError(t, condition.Validate(StringColumnType))
+
+	condition = &FilterCondition{Column: "country", Op: NotInFilterOp, Value: []interface{}{}}
+	require.Error(t, condition.Validate(StringColumnType))
+
+	condition = &FilterCondition{Column: "country", Op: InFilterOp, Value: "US"}
+	require.Error(t, condition.Validate(StringColumnType))
+
+	condition = &FilterCondition{Column: "signup_date", Op: DateRangeFilterOp, Value: []interface{}{"2019-01-01", "2019-06-30"}}
+	require.NoError(t, condition.Validate(StringColumnType))
+
+	condition = &FilterCondition{Column: "signup_date", Op: DateRangeFilterOp, Value: []interface{}{"2019-06-30", "2019-01-01"}}
+	require.Error(t, condition.Validate(StringColumnType))
+
+	condition = &FilterCondition{Column: "signup_date", Op: DateRangeFilterOp, Value: []interface{}{"2019-01-01", "June 30"}}
+	require.Error(t, condition.Validate(StringColumnType))
+
+	condition = &FilterCondition{Column: "signup_date", Op: DateRangeFilterOp, Value: []interface{}{"2019-01-01", "2019-06-30"}}
+	require.Error(t, condition.Validate(IntegerColumnType))
+
+	condition = &FilterCondition{Column: "signup_date", Op: DateRangeFilterOp, Value: []interface{}{"2019-01-01", "2019-06-30"}}
+	require.NoError(t, condition.Validate(DateColumnType))
+
+	condition = &FilterCondition{Column: "signup_date", Op: GreaterThanFilterOp, Value: "2019-01-01"}
+	require.NoError(t, condition.Validate(DateColumnType))
+
+	condition = &FilterCondition{Column: "signup_date", Op: GreaterThanFilterOp, Value: "Jan 1"}
+	require.Error(t, condition.Validate(DateColumnType))
+
+	condition = &FilterCondition{Column: "is_active", Op: EqualFilterOp, Value: true}
+	require.NoError(t, condition.Validate(BoolColumnType))
+	require.Equal(t, true, condition.Value)
+
+	condition = &FilterCondition{Column: "is_active", Op: EqualFilterOp, Value: "true"}
+	require.Error(t, condition.Validate(BoolColumnType))
+
+	condition = &FilterCondition{Column: "tags", Op: InFilterOp, Value: []interface{}{"a"}}
+	require.Error(t, condition.Validate(StringListColumnType))
+}
+
+func TestCSVIngestedColumns(t *testing.T) {
+	csvData := &CSVData{Path: "s3a://bucket/users.csv", Schema: []string{"user_id", "age"}}
+	require.Equal(t, []string{"user_id", "age"}, csvIngestedColumns(csvData))
+	require.Empty(t, csvIngestedColumns(parquetSource("events", "user_id", "tags").Data))
+
+	joinData := &JoinData{
+		Sources: DataSources{
+			{Name: "users", Data: csvData},
+			parquetSource("events", "user_id", "tags"),
+		},
+	}
+	require.Equal(t, []string{"user_id", "age"}, csvIngestedColumns(joinData))
+}
+
+func TestLimitSamplingValidate(t *testing.T) {
+	env := &Environment{
+		ResourceConfigFields: ResourceConfigFields{Name: "dev"},
+		Data:                 parquetSource("events", "user_id", "label").Data,
+		Limit:                &Limit{FractionOfRows: pointer.Float32(0.1), StratifyBy: pointer.String("label")},
+	}
+	require.NoError(t, env.Validate())
+
+	env.Limit = &Limit{FractionOfRows: pointer.Float32(0.1), SampleByKey: pointer.String("user_id"), RandomSeed: pointer.Int64(1)}
+	require.NoError(t, env.Validate())
+
+	env.Limit = &Limit{SampleByKey: pointer.String("user_id")}
+	require.Error(t, env.Validate())
+
+	env.Limit = &Limit{NumRows: pointer.Int64(100), StratifyBy: pointer.String("label"), SampleByKey: pointer.String("user_id")}
+	require.Error(t, env.Validate())
+}
+
+func TestEnvironmentValidateRawColumns(t *testing.T) {
+	rawColumns := RawColumns{
+		&RawIntColumn{ResourceConfigFields: ResourceConfigFields{Name: "user_id"}, Type: IntegerColumnType},
+		&RawIntColumn{ResourceConfigFields: ResourceConfigFields{Name: "age"}, Type: IntegerColumnType},
+		&RawFloatColumn{ResourceConfigFields: ResourceConfigFields{Name: "duration"}, Type: FloatColumnType},
+	}
+	env := &Environment{
+		ResourceConfigFields: ResourceConfigFields{Name: "dev"},
+		Data: &JoinData{
+			Sources: DataSources{
+				parquetSource("users", "user_id", "age"),
+				parquetSource("events", "user_id", "duration"),
+			},
+			Joins: []*Join{{Source: "events", Keys: []string{"user_id"}, Type: InnerJoinType}},
+		},
+	}
+	require.NoError(t, env.ValidateRawColumns(rawColumns))
+
+	// join key which isn't a raw column (it would be dropped when each source is read)
+	env.Data = &JoinData{
+		Sources: DataSources{
+			parquetSource("users", "user_id", "account_id", "age"),
+			parquetSource("events", "account_id", "duration"),
+		},
+		Joins: []*Join{{Source: "events", Keys: []string{"account_id"}, Type: InnerJoinType}},
+	}
+	require.Error(t, env.ValidateRawColumns(rawColumns))
+
+	// ingested column which isn't a raw column
+	env.Data = parquetSource("users", "user_id", "age", "duration", "country").Data
+	require.Error(t, env.ValidateRawColumns(rawColumns))
+
+	// CSV columns which aren't raw columns are ignored
+	env.Data = &CSVData{Path: "s3a://bucket/users.csv", Schema: []string{"user_id", "age", "country", "duration"}}
+	require.NoError(t, env.ValidateRawColumns(rawColumns))
+
+	// raw column which isn't ingested
+	env.Data = parquetSource("users", "user_id", "age").Data
+	require.Error(t, env.ValidateRawColumns(rawColumns))
+}
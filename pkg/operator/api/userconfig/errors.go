@@ -58,6 +58,27 @@ const (
 	ErrK8sQuantityMustBeInt
 	ErrRegressionTargetType
 	ErrClassificationTargetType
+	ErrInvalidCronSchedule
+	ErrExternalModelKey
+	ErrMultiLabelClassificationTargetType
+	ErrRankingTargetType
+	ErrEvaluationStrategyKey
+	ErrInvalidDuration
+	ErrWeightColumnType
+	ErrClassWeightModelType
+	ErrUndefinedDataSource
+	ErrDataSourceJoinedTwice
+	ErrDataSourceNotJoined
+	ErrJoinKeyNotInSources
+	ErrColumnInMultipleSources
+	ErrFilterValueType
+	ErrFilterColumnType
+	ErrInvalidFilterDate
+	ErrListColumnInCSV
+	ErrFillWithoutFillAction
+	ErrFillValueType
+	ErrInvalidPattern
+	ErrSpecifyOneOf
 )
 
 var errorKinds = []string{
@@ -90,9 +111,30 @@ var errorKinds = []string{
 	"err_k8s_quantity_must_be_int",
 	"err_regression_target_type",
 	"err_classification_target_type",
-}
-
-var _ = [1]int{}[int(ErrClassificationTargetType)-(len(errorKinds)-1)] // Ensure list length matches
+	"err_invalid_cron_schedule",
+	"err_external_model_key",
+	"err_multi_label_classification_target_type",
+	"err_ranking_target_type",
+	"err_evaluation_strategy_key",
+	"err_invalid_duration",
+	"err_weight_column_type",
+	"err_class_weight_model_type",
+	"err_undefined_data_source",
+	"err_data_source_joined_twice",
+	"err_data_source_not_joined",
+	"err_join_key_not_in_sources",
+	"err_column_in_multiple_sources",
+	"err_filter_value_type",
+	"err_filter_column_type",
+	"err_invalid_filter_date",
+	"err_list_column_in_csv",
+	"err_fill_without_fill_action",
+	"err_fill_value_type",
+	"err_invalid_pattern",
+	"err_specify_one_of",
+}
+
+var _ = [1]int{}[int(ErrSpecifyOneOf)-(len(errorKinds)-1)] // Ensure list length matches
 
 func (t ErrorKind) String() string {
 	return errorKinds[t]
@@ -283,6 +325,18 @@ func ErrorSpecifyOnlyOne(vals ...string) error {
 	}
 }
 
+func ErrorSpecifyOneOf(vals ...string) error {
+	message := fmt.Sprintf("please specify one of %s", s.UserStrsOr(vals))
+	if len(vals) == 2 {
+		message = fmt.Sprintf("please specify either %s or %s", s.UserStr(vals[0]), s.UserStr(vals[1]))
+	}
+
+	return Error{
+		Kind:    ErrSpecifyOneOf,
+		message: message,
+	}
+}
+
 func ErrorOneOfPrerequisitesNotDefined(argName string, prerequisites ...string) error {
 	message := fmt.Sprintf("%s specified without specifying %s", s.UserStr(argName), s.UserStrsOr(prerequisites))
 
@@ -382,3 +436,147 @@ func ErrorClassificationTargetType() error {
 		message: "classification models can only predict integer target values (i.e. {0, 1, ..., num_classes-1})",
 	}
 }
+
+func ErrorMultiLabelClassificationTargetType() error {
+	return Error{
+		Kind:    ErrMultiLabelClassificationTargetType,
+		message: "multi-label classification models can only predict lists of integer target values (i.e. an INT_LIST_COLUMN of class indices in {0, 1, ..., num_classes-1})",
+	}
+}
+
+func ErrorRankingTargetType() error {
+	return Error{
+		Kind:    ErrRankingTargetType,
+		message: "ranking models can only predict integer or float relevance scores",
+	}
+}
+
+func ErrorInvalidCronSchedule(schedule string, err error) error {
+	return Error{
+		Kind:    ErrInvalidCronSchedule,
+		message: fmt.Sprintf("%s is not a valid cron schedule (e.g. \"0 0 * * *\" or \"@daily\"): %s", s.UserStr(schedule), err.Error()),
+	}
+}
+
+func ErrorInvalidDuration(duration string) error {
+	return Error{
+		Kind:    ErrInvalidDuration,
+		message: fmt.Sprintf("%s is not a valid duration (e.g. \"90m\" or \"12h\")", s.UserStr(duration)),
+	}
+}
+
+func ErrorWeightColumnType(columnName string, columnType ColumnType) error {
+	return Error{
+		Kind:    ErrWeightColumnType,
+		message: fmt.Sprintf("%s must be an %s or %s (got %s)", columnName, IntegerColumnType.String(), FloatColumnType.String(), columnType.String()),
+	}
+}
+
+func ErrorClassWeightModelType() error {
+	return Error{
+		Kind:    ErrClassWeightModelType,
+		message: fmt.Sprintf("%s can only be specified for classification models with a %s", ClassWeightKey, TargetColumnKey),
+	}
+}
+
+func ErrorExternalModelKey(key string) error {
+	return Error{
+		Kind:    ErrExternalModelKey,
+		message: fmt.Sprintf("%s cannot be specified for models with %s (external models are not trained by Cortex)", key, ExternalModelKey),
+	}
+}
+
+func ErrorEvaluationStrategyKey(key string, strategy EvaluationStrategy) error {
+	return Error{
+		Kind:    ErrEvaluationStrategyKey,
+		message: fmt.Sprintf("%s cannot be specified when the evaluation %s is %s", key, StrategyKey, s.UserStr(strategy.String())),
+	}
+}
+
+func ErrorUndefinedDataSource(sourceName string) error {
+	return Error{
+		Kind:    ErrUndefinedDataSource,
+		message: fmt.Sprintf("%s is not defined in %s", s.UserStr(sourceName), SourcesKey),
+	}
+}
+
+func ErrorDataSourceJoinedTwice(sourceName string) error {
+	return Error{
+		Kind:    ErrDataSourceJoinedTwice,
+		message: fmt.Sprintf("%s has already been joined (the first source is the base of the join, and every other source must be joined exactly once)", s.UserStr(sourceName)),
+	}
+}
+
+func ErrorDataSourceNotJoined(sourceName string) error {
+	return Error{
+		Kind:    ErrDataSourceNotJoined,
+		message: fmt.Sprintf("%s is not joined (every source other than the first must be joined exactly once)", s.UserStr(sourceName)),
+	}
+}
+
+func ErrorJoinKeyNotInSources(key string, sourceName string) error {
+	return Error{
+		Kind:    ErrJoinKeyNotInSources,
+		message: fmt.Sprintf("join key %s must be a raw column in %s and in a source which is joined before it", s.UserStr(key), s.UserStr(sourceName)),
+	}
+}
+
+func ErrorColumnInMultipleSources(columnName string, sourceNames []string) error {
+	return Error{
+		Kind:    ErrColumnInMultipleSources,
+		message: fmt.Sprintf("raw column %s is mapped in multiple sources (%s), but columns which aren't join keys must come from exactly one source", s.UserStr(columnName), s.UserStrsAnd(sourceNames)),
+	}
+}
+
+func ErrorFilterValueType(op FilterOp, expected string) error {
+	return Error{
+		Kind:    ErrFilterValueType,
+		message: fmt.Sprintf("the value of a %s filter on this column must be %s", s.UserStr(op.String()), expected),
+	}
+}
+
+func ErrorFilterColumnType(op FilterOp, columnType ColumnType, allowedTypes ...ColumnType) error {
+	allowedTypeStrs := make([]string, len(allowedTypes))
+	for i, allowedType := range allowedTypes {
+		allowedTypeStrs[i] = allowedType.String()
+	}
+	return Error{
+		Kind:    ErrFilterColumnType,
+		message: fmt.Sprintf("%s filters can only be applied to %s columns (got %s)", s.UserStr(op.String()), s.StrsOr(allowedTypeStrs), columnType.String()),
+	}
+}
+
+func ErrorInvalidFilterDate(date string) error {
+	return Error{
+		Kind:    ErrInvalidFilterDate,
+		message: fmt.Sprintf("%s is not a valid date (expected format: YYYY-MM-DD)", s.UserStr(date)),
+	}
+}
+
+func ErrorListColumnInCSV(columnName string, columnType ColumnType) error {
+	return Error{
+		Kind:    ErrListColumnInCSV,
+		message: fmt.Sprintf("%s is a %s, but list columns cannot be ingested from CSV files (use parquet, json, or avro)", columnName, columnType.String()),
+	}
+}
+
+func ErrorFillWithoutFillAction(key string) error {
+	return Error{
+		Kind:    ErrFillWithoutFillAction,
+		message: fmt.Sprintf("%s can only be specified when %s is %s", key, OnNullKey, FillNullAction.String()),
+	}
+}
+
+func ErrorFillValueType(expected string) error {
+	return Error{
+		Kind:    ErrFillValueType,
+		message: fmt.Sprintf("the fill value of this column must be %s", expected),
+	}
+}
+
+func ErrorInvalidPattern(pattern string, err error) error {
+	return Error{
+		Kind:    ErrInvalidPattern,
+		message: fmt.Sprintf("%s is not a valid regular expression: %s", s.UserStr(pattern), err.Error()),
+	}
+}
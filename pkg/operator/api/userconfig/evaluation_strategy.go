@@ -0,0 +1,78 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+type EvaluationStrategy int
+
+const (
+	UnknownEvaluationStrategy EvaluationStrategy = iota
+	HoldoutEvaluationStrategy
+	KFoldEvaluationStrategy
+)
+
+var evaluationStrategies = []string{
+	"unknown",
+	"holdout",
+	"kfold",
+}
+
+func EvaluationStrategyFromString(s string) EvaluationStrategy {
+	for i := 0; i < len(evaluationStrategies); i++ {
+		if s == evaluationStrategies[i] {
+			return EvaluationStrategy(i)
+		}
+	}
+	return UnknownEvaluationStrategy
+}
+
+func EvaluationStrategyStrings() []string {
+	return evaluationStrategies[1:]
+}
+
+func (t EvaluationStrategy) String() string {
+	return evaluationStrategies[t]
+}
+
+// MarshalText satisfies TextMarshaler
+func (t EvaluationStrategy) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText satisfies TextUnmarshaler
+func (t *EvaluationStrategy) UnmarshalText(text []byte) error {
+	enum := string(text)
+	for i := 0; i < len(evaluationStrategies); i++ {
+		if enum == evaluationStrategies[i] {
+			*t = EvaluationStrategy(i)
+			return nil
+		}
+	}
+
+	*t = UnknownEvaluationStrategy
+	return nil
+}
+
+// UnmarshalBinary satisfies BinaryUnmarshaler
+// Needed for msgpack
+func (t *EvaluationStrategy) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// MarshalBinary satisfies BinaryMarshaler
+func (t EvaluationStrategy) MarshalBinary() ([]byte, error) {
+	return []byte(t.String()), nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+type FillAggregate int
+
+const (
+	UnknownFillAggregate FillAggregate = iota
+	MeanFillAggregate
+	MedianFillAggregate
+	ModeFillAggregate
+)
+
+var fillAggregates = []string{
+	"unknown",
+	"mean",
+	"median",
+	"mode",
+}
+
+func FillAggregateFromString(s string) FillAggregate {
+	for i := 0; i < len(fillAggregates); i++ {
+		if s == fillAggregates[i] {
+			return FillAggregate(i)
+		}
+	}
+	return UnknownFillAggregate
+}
+
+func FillAggregateStrings() []string {
+	return fillAggregates[1:]
+}
+
+func (t FillAggregate) String() string {
+	return fillAggregates[t]
+}
+
+// MarshalText satisfies TextMarshaler
+func (t FillAggregate) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText satisfies TextUnmarshaler
+func (t *FillAggregate) UnmarshalText(text []byte) error {
+	enum := string(text)
+	for i := 0; i < len(fillAggregates); i++ {
+		if enum == fillAggregates[i] {
+			*t = FillAggregate(i)
+			return nil
+		}
+	}
+
+	*t = UnknownFillAggregate
+	return nil
+}
+
+// UnmarshalBinary satisfies BinaryUnmarshaler
+// Needed for msgpack
+func (t *FillAggregate) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// MarshalBinary satisfies BinaryMarshaler
+func (t FillAggregate) MarshalBinary() ([]byte, error) {
+	return []byte(t.String()), nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+import (
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/cast"
+	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+)
+
+const FilterDateFormat = "2006-01-02"
+
+// Filter is a list of conditions on raw columns, all of which must be true for a row to be ingested
+type Filter []*FilterCondition
+
+type FilterCondition struct {
+	Column string      `json:"column" yaml:"column"`
+	Op     FilterOp    `json:"op" yaml:"op"`
+	Value  interface{} `json:"value" yaml:"value"`
+}
+
+var filterConditionValidation = &cr.StructValidation{
+	StructFieldValidations: []*cr.StructFieldValidation{
+		{
+			StructField: "Column",
+			StringValidation: &cr.StringValidation{
+				Required: true,
+			},
+		},
+		{
+			StructField: "Op",
+			StringValidation: &cr.StringValidation{
+				Required:      true,
+				AllowedValues: FilterOpStrings(),
+			},
+			Parser: func(str string) (interface{}, error) {
+				return FilterOpFromString(str), nil
+			},
+		},
+		{
+			StructField: "Value",
+			InterfaceValidation: &cr.InterfaceValidation{
+				Required: true,
+			},
+		},
+	},
+}
+
+// Validate checks the condition against the type of its raw column, and casts Value to that type
+func (condition *FilterCondition) Validate(columnType ColumnType) error {
+	if columnType.IsList() {
+		return ErrorUnsupportedColumnType(columnType.String(), ScalarColumnTypeStrings())
+	}
+
+	switch condition.Op {
+	case InFilterOp, NotInFilterOp:
+		values, ok := cast.InterfaceToInterfaceSlice(condition.Value)
+		if !ok || len(values) == 0 {
+			return errors.Wrap(ErrorFilterValueType(condition.Op, "a non-empty list of "+columnValueTypeStr(columnType, true)), ValueKey)
+		}
+		castedValues := make([]interface{}, len(values))
+		for i, value := range values {
+			castedValue, ok := castColumnValue(value, columnType)
+			if !ok {
+				return errors.Wrap(ErrorFilterValueType(condition.Op, "a list of "+columnValueTypeStr(columnType, true)), ValueKey, s.Index(i))
+			}
+			castedValues[i] = castedValue
+		}
+		condition.Value = castedValues
+
+	case DateRangeFilterOp:
+		if columnType != StringColumnType && columnType != DateColumnType && columnType != TimestampColumnType {
+			return ErrorFilterColumnType(condition.Op, columnType, StringColumnType, DateColumnType, TimestampColumnType)
+		}
+		dates, ok := cast.InterfaceToStrSlice(condition.Value)
+		if !ok || len(dates) != 2 {
+			return errors.Wrap(ErrorFilterValueType(condition.Op, "a list of two dates (start and end)"), ValueKey)
+		}
+		var parsedDates [2]time.Time
+		for i, date := range dates {
+			parsedDate, err := time.Parse(FilterDateFormat, date)
+			if err != nil {
+				return errors.Wrap(ErrorInvalidFilterDate(date), ValueKey, s.Index(i))
+			}
+			parsedDates[i] = parsedDate
+		}
+		if parsedDates[1].Before(parsedDates[0]) {
+			return errors.Wrap(cr.ErrorMustBeGreaterThanOrEqualTo(dates[1], dates[0]), ValueKey, s.Index(1))
+		}
+		condition.Value = []interface{}{dates[0], dates[1]}
+
+	default:
+		castedValue, ok := castColumnValue(condition.Value, columnType)
+		if !ok {
+			return errors.Wrap(ErrorFilterValueType(condition.Op, columnValueTypeStr(columnType, false)), ValueKey)
+		}
+		condition.Value = castedValue
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+type FilterOp int
+
+const (
+	UnknownFilterOp FilterOp = iota
+	EqualFilterOp
+	NotEqualFilterOp
+	LessThanFilterOp
+	LessThanOrEqualFilterOp
+	GreaterThanFilterOp
+	GreaterThanOrEqualFilterOp
+	InFilterOp
+	NotInFilterOp
+	DateRangeFilterOp
+)
+
+var filterOps = []string{
+	"unknown",
+	"eq",
+	"ne",
+	"lt",
+	"lte",
+	"gt",
+	"gte",
+	"in",
+	"not_in",
+	"date_range",
+}
+
+func FilterOpFromString(s string) FilterOp {
+	for i := 0; i < len(filterOps); i++ {
+		if s == filterOps[i] {
+			return FilterOp(i)
+		}
+	}
+	return UnknownFilterOp
+}
+
+func FilterOpStrings() []string {
+	return filterOps[1:]
+}
+
+func (t FilterOp) String() string {
+	return filterOps[t]
+}
+
+// MarshalText satisfies TextMarshaler
+func (t FilterOp) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText satisfies TextUnmarshaler
+func (t *FilterOp) UnmarshalText(text []byte) error {
+	enum := string(text)
+	for i := 0; i < len(filterOps); i++ {
+		if enum == filterOps[i] {
+			*t = FilterOp(i)
+			return nil
+		}
+	}
+
+	*t = UnknownFilterOp
+	return nil
+}
+
+// UnmarshalBinary satisfies BinaryUnmarshaler
+// Needed for msgpack
+func (t *FilterOp) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// MarshalBinary satisfies BinaryMarshaler
+func (t FilterOp) MarshalBinary() ([]byte, error) {
+	return []byte(t.String()), nil
+}
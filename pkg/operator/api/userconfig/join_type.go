@@ -0,0 +1,82 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+type JoinType int
+
+const (
+	UnknownJoinType JoinType = iota
+	InnerJoinType
+	LeftJoinType
+	RightJoinType
+	OuterJoinType
+)
+
+var joinTypes = []string{
+	"unknown",
+	"inner",
+	"left",
+	"right",
+	"outer",
+}
+
+func JoinTypeFromString(s string) JoinType {
+	for i := 0; i < len(joinTypes); i++ {
+		if s == joinTypes[i] {
+			return JoinType(i)
+		}
+	}
+	return UnknownJoinType
+}
+
+func JoinTypeStrings() []string {
+	return joinTypes[1:]
+}
+
+func (t JoinType) String() string {
+	return joinTypes[t]
+}
+
+// MarshalText satisfies TextMarshaler
+func (t JoinType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText satisfies TextUnmarshaler
+func (t *JoinType) UnmarshalText(text []byte) error {
+	enum := string(text)
+	for i := 0; i < len(joinTypes); i++ {
+		if enum == joinTypes[i] {
+			*t = JoinType(i)
+			return nil
+		}
+	}
+
+	*t = UnknownJoinType
+	return nil
+}
+
+// UnmarshalBinary satisfies BinaryUnmarshaler
+// Needed for msgpack
+func (t *JoinType) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// MarshalBinary satisfies BinaryMarshaler
+func (t JoinType) MarshalBinary() ([]byte, error) {
+	return []byte(t.String()), nil
+}
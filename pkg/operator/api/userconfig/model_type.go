@@ -22,12 +22,18 @@ const (
 	UnknownModelType ModelType = iota
 	ClassificationModelType
 	RegressionModelType
+	MultiLabelClassificationModelType
+	RankingModelType
+	CustomModelType
 )
 
 var modelTypes = []string{
 	"unknown",
 	"classification",
 	"regression",
+	"multi_label_classification",
+	"ranking",
+	"custom",
 }
 
 func ModelTypeFromString(s string) ModelType {
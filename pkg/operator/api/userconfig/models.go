@@ -19,6 +19,7 @@ package userconfig
 import (
 	"math/rand"
 
+	"github.com/cortexlabs/cortex/pkg/consts"
 	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
@@ -31,21 +32,34 @@ type Models []*Model
 type Model struct {
 	ResourceConfigFields
 	Type               ModelType                `json:"type" yaml:"type"`
+	Framework          string                   `json:"framework" yaml:"framework"`
 	Path               string                   `json:"path" yaml:"path"`
 	TargetColumn       string                   `json:"target_column" yaml:"target_column"`
+	Targets            []*ModelTarget           `json:"targets" yaml:"targets"`
 	PredictionKey      string                   `json:"prediction_key" yaml:"prediction_key"`
 	FeatureColumns     []string                 `json:"feature_columns" yaml:"feature_columns"`
 	TrainingColumns    []string                 `json:"training_columns" yaml:"training_columns"`
+	WeightColumn       string                   `json:"weight_column" yaml:"weight_column"`
+	ClassWeight        *string                  `json:"class_weight" yaml:"class_weight"`
 	Aggregates         []string                 `json:"aggregates"  yaml:"aggregates"`
 	Hparams            map[string]interface{}   `json:"hparams" yaml:"hparams"`
 	DataPartitionRatio *ModelDataPartitionRatio `json:"data_partition_ratio" yaml:"data_partition_ratio"`
 	Training           *ModelTraining           `json:"training" yaml:"training"`
 	Evaluation         *ModelEvaluation         `json:"evaluation" yaml:"evaluation"`
+	Retry              *ModelRetry              `json:"retry" yaml:"retry"`
+	WarmStart          *string                  `json:"warm_start" yaml:"warm_start"`
+	MaxDuration        *string                  `json:"max_duration" yaml:"max_duration"`
 	Compute            *TFCompute               `json:"compute" yaml:"compute"`
 	DatasetCompute     *SparkCompute            `json:"dataset_compute" yaml:"dataset_compute"`
+	Image              *string                  `json:"image" yaml:"image"`
+	Schedule           *string                  `json:"schedule" yaml:"schedule"`
+	ExternalModel      *ExternalModel           `json:"external_model" yaml:"external_model"`
 	Tags               Tags                     `json:"tags" yaml:"tags"`
 }
 
+// ClassWeightBalanced weights training examples inversely to the frequency of their class
+const ClassWeightBalanced = "balanced"
+
 var modelValidation = &cr.StructValidation{
 	StructFieldValidations: []*cr.StructFieldValidation{
 		{
@@ -73,10 +87,23 @@ var modelValidation = &cr.StructValidation{
 				return ModelTypeFromString(str), nil
 			},
 		},
+		{
+			StructField: "Framework",
+			StringValidation: &cr.StringValidation{
+				Default: consts.TensorFlowFramework,
+			},
+		},
 		{
 			StructField: "TargetColumn",
 			StringValidation: &cr.StringValidation{
-				Required: true,
+				Default:    "",
+				AllowEmpty: true,
+			},
+		},
+		{
+			StructField: "Targets",
+			StructListValidation: &cr.StructListValidation{
+				StructValidation: modelTargetValidation,
 			},
 		},
 		{
@@ -101,6 +128,19 @@ var modelValidation = &cr.StructValidation{
 				Default:      make([]string, 0),
 			},
 		},
+		{
+			StructField: "WeightColumn",
+			StringValidation: &cr.StringValidation{
+				Default:    "",
+				AllowEmpty: true,
+			},
+		},
+		{
+			StructField: "ClassWeight",
+			StringPtrValidation: &cr.StringPtrValidation{
+				AllowedValues: []string{ClassWeightBalanced},
+			},
+		},
 		{
 			StructField: "Aggregates",
 			StringListValidation: &cr.StringListValidation{
@@ -127,13 +167,90 @@ var modelValidation = &cr.StructValidation{
 			StructField:      "Evaluation",
 			StructValidation: modelEvaluationValidation,
 		},
+		{
+			StructField:      "Retry",
+			StructValidation: modelRetryValidation,
+		},
+		{
+			StructField:         "WarmStart",
+			StringPtrValidation: &cr.StringPtrValidation{},
+		},
+		{
+			StructField: "MaxDuration",
+			StringPtrValidation: &cr.StringPtrValidation{
+				Validator: ValidateDuration,
+			},
+		},
+		{
+			StructField: "Schedule",
+			StringPtrValidation: &cr.StringPtrValidation{
+				Validator: ValidateSchedule,
+			},
+		},
+		{
+			StructField:      "ExternalModel",
+			StructValidation: externalModelValidation,
+		},
 		tfComputeFieldValidation,
 		sparkComputeFieldValidation("DatasetCompute"),
+		imageFieldValidation,
 		tagsFieldValidation,
 		typeFieldValidation,
 	},
 }
 
+// ModelTarget is one of the columns predicted by a multi-target model
+type ModelTarget struct {
+	Column        string    `json:"column" yaml:"column"`
+	Type          ModelType `json:"type" yaml:"type"`
+	PredictionKey string    `json:"prediction_key" yaml:"prediction_key"`
+}
+
+var modelTargetValidation = &cr.StructValidation{
+	StructFieldValidations: []*cr.StructFieldValidation{
+		{
+			StructField: "Column",
+			StringValidation: &cr.StringValidation{
+				Required: true,
+			},
+		},
+		{
+			StructField: "Type",
+			StringValidation: &cr.StringValidation{
+				Default:       ClassificationModelType.String(),
+				AllowedValues: ModelTypeStrings(),
+			},
+			Parser: func(str string) (interface{}, error) {
+				return ModelTypeFromString(str), nil
+			},
+		},
+		{
+			StructField: "PredictionKey",
+			StringValidation: &cr.StringValidation{
+				Default:    "",
+				AllowEmpty: true,
+			},
+		},
+	},
+}
+
+// ExternalModel points to a TensorFlow SavedModel which was exported outside of Cortex
+type ExternalModel struct {
+	Path string `json:"path" yaml:"path"`
+}
+
+var externalModelValidation = &cr.StructValidation{
+	DefualtNil: true,
+	StructFieldValidations: []*cr.StructFieldValidation{
+		{
+			StructField: "Path",
+			StringValidation: cr.GetS3aPathValidation(&cr.S3aPathValidation{
+				Required: true,
+			}),
+		},
+	},
+}
+
 type ModelDataPartitionRatio struct {
 	Training   *float64 `json:"training"`
 	Evaluation *float64 `json:"evaluation"`
@@ -156,6 +273,31 @@ var modelDataPartitionRatioValidation = &cr.StructValidation{
 	},
 }
 
+// ModelRetry configures how failed training workloads are retried (it doesn't affect the model's ID)
+type ModelRetry struct {
+	MaxRetries  int64 `json:"max_retries" yaml:"max_retries"`
+	BackoffSecs int64 `json:"backoff_secs" yaml:"backoff_secs"`
+}
+
+var modelRetryValidation = &cr.StructValidation{
+	StructFieldValidations: []*cr.StructFieldValidation{
+		{
+			StructField: "MaxRetries",
+			Int64Validation: &cr.Int64Validation{
+				GreaterThanOrEqualTo: pointer.Int64(0),
+				Default:              0,
+			},
+		},
+		{
+			StructField: "BackoffSecs",
+			Int64Validation: &cr.Int64Validation{
+				GreaterThanOrEqualTo: pointer.Int64(0),
+				Default:              30,
+			},
+		},
+	},
+}
+
 type ModelTraining struct {
 	BatchSize                 int64  `json:"batch_size" yaml:"batch_size"`
 	NumSteps                  *int64 `json:"num_steps" yaml:"num_steps"`
@@ -259,16 +401,35 @@ var modelTrainingValidation = &cr.StructValidation{
 }
 
 type ModelEvaluation struct {
-	BatchSize      int64  `json:"batch_size" yaml:"batch_size"`
-	NumSteps       *int64 `json:"num_steps" yaml:"num_steps"`
-	NumEpochs      *int64 `json:"num_epochs" yaml:"num_epochs"`
-	Shuffle        bool   `json:"shuffle" yaml:"shuffle"`
-	StartDelaySecs int64  `json:"start_delay_secs" yaml:"start_delay_secs"`
-	ThrottleSecs   int64  `json:"throttle_secs" yaml:"throttle_secs"`
+	Strategy          EvaluationStrategy `json:"strategy" yaml:"strategy"`
+	Folds             *int64             `json:"folds" yaml:"folds"`
+	BatchSize         int64              `json:"batch_size" yaml:"batch_size"`
+	NumSteps          *int64             `json:"num_steps" yaml:"num_steps"`
+	NumEpochs         *int64             `json:"num_epochs" yaml:"num_epochs"`
+	Shuffle           bool               `json:"shuffle" yaml:"shuffle"`
+	StartDelaySecs    int64              `json:"start_delay_secs" yaml:"start_delay_secs"`
+	ThrottleSecs      int64              `json:"throttle_secs" yaml:"throttle_secs"`
+	FeatureImportance bool               `json:"feature_importance" yaml:"feature_importance"`
 }
 
 var modelEvaluationValidation = &cr.StructValidation{
 	StructFieldValidations: []*cr.StructFieldValidation{
+		{
+			StructField: "Strategy",
+			StringValidation: &cr.StringValidation{
+				Default:       HoldoutEvaluationStrategy.String(),
+				AllowedValues: EvaluationStrategyStrings(),
+			},
+			Parser: func(str string) (interface{}, error) {
+				return EvaluationStrategyFromString(str), nil
+			},
+		},
+		{
+			StructField: "Folds",
+			Int64PtrValidation: &cr.Int64PtrValidation{
+				GreaterThan: pointer.Int64(1),
+			},
+		},
 		{
 			StructField: "BatchSize",
 			Int64Validation: &cr.Int64Validation{
@@ -308,6 +469,12 @@ var modelEvaluationValidation = &cr.StructValidation{
 				Default:     600,
 			},
 		},
+		{
+			StructField: "FeatureImportance",
+			BoolValidation: &cr.BoolValidation{
+				Default: false,
+			},
+		},
 	},
 }
 
@@ -332,6 +499,62 @@ func (models Models) Validate() error {
 }
 
 func (model *Model) Validate() error {
+	if model.IsExternal() {
+		if len(model.TrainingColumns) > 0 {
+			return errors.Wrap(ErrorExternalModelKey(TrainingColumnsKey), Identify(model))
+		}
+		if len(model.Aggregates) > 0 {
+			return errors.Wrap(ErrorExternalModelKey(AggregatesKey), Identify(model))
+		}
+		if model.WarmStart != nil {
+			return errors.Wrap(ErrorExternalModelKey(WarmStartKey), Identify(model))
+		}
+		if model.Image != nil {
+			return errors.Wrap(ErrorExternalModelKey(ImageKey), Identify(model))
+		}
+		if model.WeightColumn != "" {
+			return errors.Wrap(ErrorExternalModelKey(WeightColumnKey), Identify(model))
+		}
+		if model.ClassWeight != nil {
+			return errors.Wrap(ErrorExternalModelKey(ClassWeightKey), Identify(model))
+		}
+		if model.Evaluation.FeatureImportance {
+			return errors.Wrap(ErrorExternalModelKey(FeatureImportanceKey), Identify(model), EvaluationKey)
+		}
+		if model.Evaluation.Strategy == KFoldEvaluationStrategy {
+			return errors.Wrap(ErrorExternalModelKey(StrategyKey), Identify(model), EvaluationKey)
+		}
+	}
+
+	if model.ClassWeight != nil && (model.TargetColumn == "" || model.Type != ClassificationModelType) {
+		return errors.Wrap(ErrorClassWeightModelType(), Identify(model), ClassWeightKey)
+	}
+
+	if model.TargetColumn != "" && len(model.Targets) > 0 {
+		return errors.Wrap(ErrorSpecifyOnlyOne(TargetColumnKey, TargetsKey), Identify(model))
+	} else if model.TargetColumn == "" && len(model.Targets) == 0 && !model.IsExternal() {
+		return errors.Wrap(ErrorSpecifyOneOf(TargetColumnKey, TargetsKey), Identify(model))
+	}
+
+	targetColumns := make([]string, len(model.Targets))
+	for i, target := range model.Targets {
+		targetColumns[i] = target.Column
+	}
+	if dups := slices.FindDuplicateStrs(targetColumns); len(dups) > 0 {
+		return errors.Wrap(cr.ErrorDuplicatedValue(dups[0]), Identify(model), TargetsKey)
+	}
+
+	if model.Evaluation.Strategy == KFoldEvaluationStrategy {
+		if model.DataPartitionRatio.Training != nil || model.DataPartitionRatio.Evaluation != nil {
+			return errors.Wrap(ErrorEvaluationStrategyKey(DataPartitionRatioKey, model.Evaluation.Strategy), Identify(model))
+		}
+		if model.Evaluation.Folds == nil {
+			model.Evaluation.Folds = pointer.Int64(5)
+		}
+	} else if model.Evaluation.Folds != nil {
+		return errors.Wrap(ErrorEvaluationStrategyKey(FoldsKey, model.Evaluation.Strategy), Identify(model), EvaluationKey)
+	}
+
 	if model.DataPartitionRatio.Training == nil && model.DataPartitionRatio.Evaluation == nil {
 		model.DataPartitionRatio.Training = pointer.Float64(0.8)
 		model.DataPartitionRatio.Evaluation = pointer.Float64(0.2)
@@ -367,7 +590,38 @@ func (model *Model) Validate() error {
 }
 
 func (model *Model) AllColumnNames() []string {
-	return slices.MergeStrSlices(model.FeatureColumns, model.TrainingColumns, []string{model.TargetColumn})
+	columnNames := slices.MergeStrSlices(model.FeatureColumns, model.TrainingColumns, model.TargetColumnNames())
+	if model.WeightColumn != "" {
+		columnNames = slices.MergeStrSlices(columnNames, []string{model.WeightColumn})
+	}
+	return columnNames
+}
+
+// TargetColumnNames returns the model's target columns (either target_column, or the columns in targets)
+func (model *Model) TargetColumnNames() []string {
+	if len(model.Targets) > 0 {
+		names := make([]string, len(model.Targets))
+		for i, target := range model.Targets {
+			names[i] = target.Column
+		}
+		return names
+	}
+	if model.TargetColumn != "" {
+		return []string{model.TargetColumn}
+	}
+	return nil
+}
+
+func (model *Model) IsExternal() bool {
+	return model.ExternalModel != nil
+}
+
+// NumFolds returns the number of cross-validation folds, or 0 if the model isn't evaluated with k-fold cross-validation
+func (model *Model) NumFolds() int {
+	if model.Evaluation.Strategy != KFoldEvaluationStrategy {
+		return 0
+	}
+	return int(*model.Evaluation.Folds)
 }
 
 func (model *Model) GetResourceType() resource.Type {
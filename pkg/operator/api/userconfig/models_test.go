@@ -0,0 +1,97 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+)
+
+func newTestModel() *Model {
+	return &Model{
+		ResourceConfigFields: ResourceConfigFields{Name: "test"},
+		Type:                 ClassificationModelType,
+		FeatureColumns:       []string{"feature1", "feature2"},
+		DataPartitionRatio:   &ModelDataPartitionRatio{},
+		Training:             &ModelTraining{},
+		Evaluation:           &ModelEvaluation{Strategy: HoldoutEvaluationStrategy},
+	}
+}
+
+func requireErrorKind(t *testing.T, kind ErrorKind, err error) {
+	require.Error(t, err)
+	userconfigErr, ok := errors.Cause(err).(Error)
+	require.True(t, ok, err.Error())
+	require.Equal(t, kind, userconfigErr.Kind, err.Error())
+}
+
+func TestModelValidateTargets(t *testing.T) {
+	model := newTestModel()
+	model.TargetColumn = "label"
+	require.NoError(t, model.Validate())
+	require.Equal(t, []string{"label"}, model.TargetColumnNames())
+
+	model = newTestModel()
+	model.Targets = []*ModelTarget{
+		{Column: "label1", Type: ClassificationModelType},
+		{Column: "label2", Type: RegressionModelType},
+	}
+	require.NoError(t, model.Validate())
+	require.Equal(t, []string{"label1", "label2"}, model.TargetColumnNames())
+	require.ElementsMatch(t, []string{"feature1", "feature2", "label1", "label2"}, model.AllColumnNames())
+
+	model = newTestModel()
+	requireErrorKind(t, ErrSpecifyOneOf, model.Validate())
+
+	model = newTestModel()
+	model.TargetColumn = "label"
+	model.Targets = []*ModelTarget{{Column: "label1", Type: ClassificationModelType}}
+	requireErrorKind(t, ErrSpecifyOnlyOne, model.Validate())
+
+	model = newTestModel()
+	model.Targets = []*ModelTarget{
+		{Column: "label1", Type: ClassificationModelType},
+		{Column: "label1", Type: RegressionModelType},
+	}
+	require.Error(t, model.Validate())
+
+	model = newTestModel()
+	model.Targets = []*ModelTarget{{Column: "label1", Type: ClassificationModelType}}
+	model.ClassWeight = pointer.String(ClassWeightBalanced)
+	requireErrorKind(t, ErrClassWeightModelType, model.Validate())
+}
+
+func TestModelValidateExternal(t *testing.T) {
+	model := newTestModel()
+	model.ExternalModel = &ExternalModel{Path: "s3://bucket/model"}
+	require.NoError(t, model.Validate())
+
+	model = newTestModel()
+	model.ExternalModel = &ExternalModel{Path: "s3://bucket/model"}
+	model.Evaluation.Strategy = KFoldEvaluationStrategy
+	requireErrorKind(t, ErrExternalModelKey, model.Validate())
+
+	model = newTestModel()
+	model.TargetColumn = "label"
+	model.Evaluation.Strategy = KFoldEvaluationStrategy
+	require.NoError(t, model.Validate())
+	require.Equal(t, int64(5), *model.Evaluation.Folds)
+}
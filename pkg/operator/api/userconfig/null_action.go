@@ -0,0 +1,80 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+type NullAction int
+
+const (
+	UnknownNullAction NullAction = iota
+	DropNullAction
+	FailNullAction
+	FillNullAction
+)
+
+var nullActions = []string{
+	"unknown",
+	"drop",
+	"fail",
+	"fill",
+}
+
+func NullActionFromString(s string) NullAction {
+	for i := 0; i < len(nullActions); i++ {
+		if s == nullActions[i] {
+			return NullAction(i)
+		}
+	}
+	return UnknownNullAction
+}
+
+func NullActionStrings() []string {
+	return nullActions[1:]
+}
+
+func (t NullAction) String() string {
+	return nullActions[t]
+}
+
+// MarshalText satisfies TextMarshaler
+func (t NullAction) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText satisfies TextUnmarshaler
+func (t *NullAction) UnmarshalText(text []byte) error {
+	enum := string(text)
+	for i := 0; i < len(nullActions); i++ {
+		if enum == nullActions[i] {
+			*t = NullAction(i)
+			return nil
+		}
+	}
+
+	*t = UnknownNullAction
+	return nil
+}
+
+// UnmarshalBinary satisfies BinaryUnmarshaler
+// Needed for msgpack
+func (t *NullAction) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// MarshalBinary satisfies BinaryMarshaler
+func (t NullAction) MarshalBinary() ([]byte, error) {
+	return []byte(t.String()), nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+import (
+	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+// NullHandling describes what to do with a raw column's null values during ingestion and serving
+type NullHandling struct {
+	OnNull        *NullAction    `json:"on_null" yaml:"on_null"`
+	FillValue     interface{}    `json:"fill_value" yaml:"fill_value"`
+	FillAggregate *FillAggregate `json:"fill_aggregate" yaml:"fill_aggregate"`
+}
+
+var nullHandlingFieldValidations = []*cr.StructFieldValidation{
+	{
+		Key:         OnNullKey,
+		StructField: "OnNull",
+		StringPtrValidation: &cr.StringPtrValidation{
+			AllowedValues: NullActionStrings(),
+		},
+		Parser: func(str string) (interface{}, error) {
+			return NullActionFromString(str), nil
+		},
+	},
+	{
+		Key:                 FillValueKey,
+		StructField:         "FillValue",
+		InterfaceValidation: &cr.InterfaceValidation{},
+	},
+	{
+		Key:         FillAggregateKey,
+		StructField: "FillAggregate",
+		StringPtrValidation: &cr.StringPtrValidation{
+			AllowedValues: FillAggregateStrings(),
+		},
+		Parser: func(str string) (interface{}, error) {
+			return FillAggregateFromString(str), nil
+		},
+	},
+}
+
+func (nullHandling *NullHandling) GetNullHandling() *NullHandling {
+	return nullHandling
+}
+
+// IsSet is false when the column keeps its null values (the default)
+func (nullHandling *NullHandling) IsSet() bool {
+	return nullHandling.OnNull != nil
+}
+
+// Validate checks the fill settings against the type of the raw column, and casts FillValue to that type
+func (nullHandling *NullHandling) Validate(columnType ColumnType) error {
+	if nullHandling.OnNull == nil || *nullHandling.OnNull != FillNullAction {
+		if nullHandling.FillValue != nil {
+			return ErrorFillWithoutFillAction(FillValueKey)
+		}
+		if nullHandling.FillAggregate != nil {
+			return ErrorFillWithoutFillAction(FillAggregateKey)
+		}
+		return nil
+	}
+
+	if (nullHandling.FillValue == nil) == (nullHandling.FillAggregate == nil) {
+		return ErrorSpecifyOnlyOne(FillValueKey, FillAggregateKey)
+	}
+
+	if nullHandling.FillValue != nil {
+		castedValue, ok := castColumnValue(nullHandling.FillValue, columnType)
+		if !ok {
+			return errors.Wrap(ErrorFillValueType(columnValueTypeStr(columnType, false)), FillValueKey)
+		}
+		nullHandling.FillValue = castedValue
+		return nil
+	}
+
+	switch *nullHandling.FillAggregate {
+	case MeanFillAggregate, MedianFillAggregate:
+		if columnType != IntegerColumnType && columnType != FloatColumnType {
+			return errors.Wrap(ErrorUnsupportedColumnType(columnType.String(), []string{IntegerColumnType.String(), FloatColumnType.String()}), FillAggregateKey)
+		}
+	case ModeFillAggregate:
+		if columnType.IsList() {
+			return errors.Wrap(ErrorUnsupportedColumnType(columnType.String(), ScalarColumnTypeStrings()), FillAggregateKey)
+		}
+	}
+
+	return nil
+}
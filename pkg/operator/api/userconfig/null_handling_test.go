@@ -0,0 +1,74 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func nullAction(action NullAction) *NullAction {
+	return &action
+}
+
+func fillAggregate(aggregate FillAggregate) *FillAggregate {
+	return &aggregate
+}
+
+func TestNullHandlingValidate(t *testing.T) {
+	nullHandling := &NullHandling{}
+	require.NoError(t, nullHandling.Validate(IntegerColumnType))
+	require.False(t, nullHandling.IsSet())
+
+	nullHandling = &NullHandling{OnNull: nullAction(DropNullAction)}
+	require.NoError(t, nullHandling.Validate(StringListColumnType))
+
+	nullHandling = &NullHandling{OnNull: nullAction(DropNullAction), FillValue: 1}
+	require.Error(t, nullHandling.Validate(IntegerColumnType))
+
+	nullHandling = &NullHandling{FillAggregate: fillAggregate(MeanFillAggregate)}
+	require.Error(t, nullHandling.Validate(IntegerColumnType))
+
+	nullHandling = &NullHandling{OnNull: nullAction(FillNullAction)}
+	require.Error(t, nullHandling.Validate(IntegerColumnType))
+
+	nullHandling = &NullHandling{OnNull: nullAction(FillNullAction), FillValue: 0, FillAggregate: fillAggregate(MeanFillAggregate)}
+	require.Error(t, nullHandling.Validate(IntegerColumnType))
+
+	nullHandling = &NullHandling{OnNull: nullAction(FillNullAction), FillValue: 0}
+	require.NoError(t, nullHandling.Validate(FloatColumnType))
+	require.Equal(t, float64(0), nullHandling.FillValue)
+
+	nullHandling = &NullHandling{OnNull: nullAction(FillNullAction), FillValue: "unknown"}
+	require.Error(t, nullHandling.Validate(IntegerColumnType))
+
+	nullHandling = &NullHandling{OnNull: nullAction(FillNullAction), FillValue: []interface{}{1, 2}}
+	require.NoError(t, nullHandling.Validate(IntegerListColumnType))
+	require.Equal(t, []interface{}{int64(1), int64(2)}, nullHandling.FillValue)
+
+	nullHandling = &NullHandling{OnNull: nullAction(FillNullAction), FillValue: "2019-03-01"}
+	require.NoError(t, nullHandling.Validate(DateColumnType))
+
+	nullHandling = &NullHandling{OnNull: nullAction(FillNullAction), FillAggregate: fillAggregate(MedianFillAggregate)}
+	require.NoError(t, nullHandling.Validate(IntegerColumnType))
+	require.Error(t, nullHandling.Validate(StringColumnType))
+
+	nullHandling = &NullHandling{OnNull: nullAction(FillNullAction), FillAggregate: fillAggregate(ModeFillAggregate)}
+	require.NoError(t, nullHandling.Validate(StringColumnType))
+	require.Error(t, nullHandling.Validate(StringListColumnType))
+}
@@ -17,7 +17,11 @@ limitations under the License.
 package userconfig
 
 import (
+	"regexp"
+
 	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
 	"github.com/cortexlabs/cortex/pkg/operator/api/resource"
 )
 
@@ -25,6 +29,8 @@ type RawColumn interface {
 	Column
 	GetType() ColumnType
 	GetCompute() *SparkCompute
+	GetNullHandling() *NullHandling
+	GetDataQuality() *DataQuality
 	GetUserConfig() Resource
 }
 
@@ -46,14 +52,43 @@ var rawColumnValidation = &cr.InterfaceStructValidation{
 			Type:                   (*RawFloatColumn)(nil),
 			StructFieldValidations: rawFloatColumnFieldValidations,
 		},
+		BoolColumnType: {
+			Type:                   (*RawBoolColumn)(nil),
+			StructFieldValidations: rawBoolColumnFieldValidations,
+		},
+		TimestampColumnType: {
+			Type:                   (*RawTimestampColumn)(nil),
+			StructFieldValidations: rawTimestampColumnFieldValidations,
+		},
+		DateColumnType: {
+			Type:                   (*RawDateColumn)(nil),
+			StructFieldValidations: rawDateColumnFieldValidations,
+		},
+		IntegerListColumnType: {
+			Type:                   (*RawIntListColumn)(nil),
+			StructFieldValidations: rawIntListColumnFieldValidations,
+		},
+		FloatListColumnType: {
+			Type:                   (*RawFloatListColumn)(nil),
+			StructFieldValidations: rawFloatListColumnFieldValidations,
+		},
+		StringListColumnType: {
+			Type:                   (*RawStringListColumn)(nil),
+			StructFieldValidations: rawStringListColumnFieldValidations,
+		},
 	},
 	Parser: func(str string) (interface{}, error) {
 		return ColumnTypeFromString(str), nil
 	},
 }
 
+// sharedRawColumnFieldValidations are the null handling and data quality settings, which apply to every raw column type
+var sharedRawColumnFieldValidations = append(append([]*cr.StructFieldValidation{}, nullHandlingFieldValidations...), dataQualityFieldValidations...)
+
 type RawIntColumn struct {
 	ResourceConfigFields
+	NullHandling
+	DataQuality
 	Type     ColumnType    `json:"type" yaml:"type"`
 	Required bool          `json:"required" yaml:"required"`
 	Min      *int64        `json:"min" yaml:"min"`
@@ -63,7 +98,7 @@ type RawIntColumn struct {
 	Tags     Tags          `json:"tags" yaml:"tags"`
 }
 
-var rawIntColumnFieldValidations = []*cr.StructFieldValidation{
+var rawIntColumnFieldValidations = append([]*cr.StructFieldValidation{
 	{
 		Key:         "name",
 		StructField: "Name",
@@ -97,10 +132,12 @@ var rawIntColumnFieldValidations = []*cr.StructFieldValidation{
 	sparkComputeFieldValidation("Compute"),
 	tagsFieldValidation,
 	typeFieldValidation,
-}
+}, sharedRawColumnFieldValidations...)
 
 type RawFloatColumn struct {
 	ResourceConfigFields
+	NullHandling
+	DataQuality
 	Type     ColumnType    `json:"type" yaml:"type"`
 	Required bool          `json:"required" yaml:"required"`
 	Min      *float32      `json:"min" yaml:"min"`
@@ -110,7 +147,7 @@ type RawFloatColumn struct {
 	Tags     Tags          `json:"tags" yaml:"tags"`
 }
 
-var rawFloatColumnFieldValidations = []*cr.StructFieldValidation{
+var rawFloatColumnFieldValidations = append([]*cr.StructFieldValidation{
 	{
 		Key:         "name",
 		StructField: "Name",
@@ -144,24 +181,314 @@ var rawFloatColumnFieldValidations = []*cr.StructFieldValidation{
 	sparkComputeFieldValidation("Compute"),
 	tagsFieldValidation,
 	typeFieldValidation,
-}
+}, sharedRawColumnFieldValidations...)
 
+// RawStringColumn's Pattern, MinLength and MaxLength constrain each value of the column
 type RawStringColumn struct {
 	ResourceConfigFields
+	NullHandling
+	DataQuality
+	Type      ColumnType    `json:"type" yaml:"type"`
+	Required  bool          `json:"required" yaml:"required"`
+	Values    []string      `json:"values" yaml:"values"`
+	Pattern   *string       `json:"pattern" yaml:"pattern"`
+	MinLength *int64        `json:"min_length" yaml:"min_length"`
+	MaxLength *int64        `json:"max_length" yaml:"max_length"`
+	Compute   *SparkCompute `json:"compute" yaml:"compute"`
+	Tags      Tags          `json:"tags" yaml:"tags"`
+}
+
+var rawStringColumnFieldValidations = append([]*cr.StructFieldValidation{
+	{
+		Key:         "name",
+		StructField: "Name",
+		StringValidation: &cr.StringValidation{
+			AlphaNumericDashUnderscore: true,
+			Required:                   true,
+		},
+	},
+	{
+		Key:         "required",
+		StructField: "Required",
+		BoolValidation: &cr.BoolValidation{
+			Default: false,
+		},
+	},
+	{
+		Key:                  "values",
+		StructField:          "Values",
+		StringListValidation: &cr.StringListValidation{},
+	},
+	{
+		Key:                 PatternKey,
+		StructField:         "Pattern",
+		StringPtrValidation: &cr.StringPtrValidation{},
+	},
+	{
+		Key:         MinLengthKey,
+		StructField: "MinLength",
+		Int64PtrValidation: &cr.Int64PtrValidation{
+			GreaterThanOrEqualTo: pointer.Int64(0),
+		},
+	},
+	{
+		Key:         MaxLengthKey,
+		StructField: "MaxLength",
+		Int64PtrValidation: &cr.Int64PtrValidation{
+			GreaterThan: pointer.Int64(0),
+		},
+	},
+	sparkComputeFieldValidation("Compute"),
+	tagsFieldValidation,
+	typeFieldValidation,
+}, sharedRawColumnFieldValidations...)
+
+// Validate checks that Pattern is a valid regular expression, and that MinLength and MaxLength are consistent
+func (column *RawStringColumn) Validate() error {
+	if column.Pattern != nil {
+		if _, err := regexp.Compile(*column.Pattern); err != nil {
+			return errors.Wrap(ErrorInvalidPattern(*column.Pattern, err), PatternKey)
+		}
+	}
+
+	if column.MinLength != nil && column.MaxLength != nil && *column.MinLength > *column.MaxLength {
+		return errors.Wrap(cr.ErrorMustBeLessThanOrEqualTo(*column.MinLength, *column.MaxLength), MinLengthKey)
+	}
+
+	return nil
+}
+
+type RawBoolColumn struct {
+	ResourceConfigFields
+	NullHandling
+	DataQuality
 	Type     ColumnType    `json:"type" yaml:"type"`
 	Required bool          `json:"required" yaml:"required"`
-	Values   []string      `json:"values" yaml:"values"`
 	Compute  *SparkCompute `json:"compute" yaml:"compute"`
 	Tags     Tags          `json:"tags" yaml:"tags"`
 }
 
-var rawStringColumnFieldValidations = []*cr.StructFieldValidation{
+var rawBoolColumnFieldValidations = append([]*cr.StructFieldValidation{
+	{
+		Key:         "name",
+		StructField: "Name",
+		StringValidation: &cr.StringValidation{
+			Required:                   true,
+			AlphaNumericDashUnderscore: true,
+		},
+	},
+	{
+		Key:         "required",
+		StructField: "Required",
+		BoolValidation: &cr.BoolValidation{
+			Default: false,
+		},
+	},
+	sparkComputeFieldValidation("Compute"),
+	tagsFieldValidation,
+	typeFieldValidation,
+}, sharedRawColumnFieldValidations...)
+
+// RawTimestampColumn values are stored as UTC timestamps, and are passed to models as seconds since the Unix epoch
+type RawTimestampColumn struct {
+	ResourceConfigFields
+	NullHandling
+	DataQuality
+	Type     ColumnType    `json:"type" yaml:"type"`
+	Required bool          `json:"required" yaml:"required"`
+	Compute  *SparkCompute `json:"compute" yaml:"compute"`
+	Tags     Tags          `json:"tags" yaml:"tags"`
+}
+
+var rawTimestampColumnFieldValidations = append([]*cr.StructFieldValidation{
+	{
+		Key:         "name",
+		StructField: "Name",
+		StringValidation: &cr.StringValidation{
+			Required:                   true,
+			AlphaNumericDashUnderscore: true,
+		},
+	},
+	{
+		Key:         "required",
+		StructField: "Required",
+		BoolValidation: &cr.BoolValidation{
+			Default: false,
+		},
+	},
+	sparkComputeFieldValidation("Compute"),
+	tagsFieldValidation,
+	typeFieldValidation,
+}, sharedRawColumnFieldValidations...)
+
+// RawDateColumn values are passed to models as days since the Unix epoch
+type RawDateColumn struct {
+	ResourceConfigFields
+	NullHandling
+	DataQuality
+	Type     ColumnType    `json:"type" yaml:"type"`
+	Required bool          `json:"required" yaml:"required"`
+	Compute  *SparkCompute `json:"compute" yaml:"compute"`
+	Tags     Tags          `json:"tags" yaml:"tags"`
+}
+
+var rawDateColumnFieldValidations = append([]*cr.StructFieldValidation{
+	{
+		Key:         "name",
+		StructField: "Name",
+		StringValidation: &cr.StringValidation{
+			Required:                   true,
+			AlphaNumericDashUnderscore: true,
+		},
+	},
+	{
+		Key:         "required",
+		StructField: "Required",
+		BoolValidation: &cr.BoolValidation{
+			Default: false,
+		},
+	},
+	sparkComputeFieldValidation("Compute"),
+	tagsFieldValidation,
+	typeFieldValidation,
+}, sharedRawColumnFieldValidations...)
+
+// RawIntListColumn's Min, Max and Values constrain each element of the list, and MaxLength constrains the list's length
+type RawIntListColumn struct {
+	ResourceConfigFields
+	NullHandling
+	DataQuality
+	Type      ColumnType    `json:"type" yaml:"type"`
+	Required  bool          `json:"required" yaml:"required"`
+	Min       *int64        `json:"min" yaml:"min"`
+	Max       *int64        `json:"max" yaml:"max"`
+	Values    []int64       `json:"values" yaml:"values"`
+	MaxLength *int64        `json:"max_length" yaml:"max_length"`
+	Compute   *SparkCompute `json:"compute" yaml:"compute"`
+	Tags      Tags          `json:"tags" yaml:"tags"`
+}
+
+var rawIntListColumnFieldValidations = append([]*cr.StructFieldValidation{
+	{
+		Key:         "name",
+		StructField: "Name",
+		StringValidation: &cr.StringValidation{
+			Required:                   true,
+			AlphaNumericDashUnderscore: true,
+		},
+	},
+	{
+		Key:         "required",
+		StructField: "Required",
+		BoolValidation: &cr.BoolValidation{
+			Default: false,
+		},
+	},
+	{
+		Key:                "min",
+		StructField:        "Min",
+		Int64PtrValidation: &cr.Int64PtrValidation{},
+	},
+	{
+		Key:                "max",
+		StructField:        "Max",
+		Int64PtrValidation: &cr.Int64PtrValidation{},
+	},
+	{
+		Key:                 "values",
+		StructField:         "Values",
+		Int64ListValidation: &cr.Int64ListValidation{},
+	},
+	{
+		Key:         "max_length",
+		StructField: "MaxLength",
+		Int64PtrValidation: &cr.Int64PtrValidation{
+			GreaterThan: pointer.Int64(0),
+		},
+	},
+	sparkComputeFieldValidation("Compute"),
+	tagsFieldValidation,
+	typeFieldValidation,
+}, sharedRawColumnFieldValidations...)
+
+// RawFloatListColumn's Min, Max and Values constrain each element of the list, and MaxLength constrains the list's length
+type RawFloatListColumn struct {
+	ResourceConfigFields
+	NullHandling
+	DataQuality
+	Type      ColumnType    `json:"type" yaml:"type"`
+	Required  bool          `json:"required" yaml:"required"`
+	Min       *float32      `json:"min" yaml:"min"`
+	Max       *float32      `json:"max" yaml:"max"`
+	Values    []float32     `json:"values" yaml:"values"`
+	MaxLength *int64        `json:"max_length" yaml:"max_length"`
+	Compute   *SparkCompute `json:"compute" yaml:"compute"`
+	Tags      Tags          `json:"tags" yaml:"tags"`
+}
+
+var rawFloatListColumnFieldValidations = append([]*cr.StructFieldValidation{
 	{
 		Key:         "name",
 		StructField: "Name",
 		StringValidation: &cr.StringValidation{
+			Required:                   true,
 			AlphaNumericDashUnderscore: true,
+		},
+	},
+	{
+		Key:         "required",
+		StructField: "Required",
+		BoolValidation: &cr.BoolValidation{
+			Default: false,
+		},
+	},
+	{
+		Key:                  "min",
+		StructField:          "Min",
+		Float32PtrValidation: &cr.Float32PtrValidation{},
+	},
+	{
+		Key:                  "max",
+		StructField:          "Max",
+		Float32PtrValidation: &cr.Float32PtrValidation{},
+	},
+	{
+		Key:                   "values",
+		StructField:           "Values",
+		Float32ListValidation: &cr.Float32ListValidation{},
+	},
+	{
+		Key:         "max_length",
+		StructField: "MaxLength",
+		Int64PtrValidation: &cr.Int64PtrValidation{
+			GreaterThan: pointer.Int64(0),
+		},
+	},
+	sparkComputeFieldValidation("Compute"),
+	tagsFieldValidation,
+	typeFieldValidation,
+}, sharedRawColumnFieldValidations...)
+
+// RawStringListColumn's Values constrain each element of the list, and MaxLength constrains the list's length
+type RawStringListColumn struct {
+	ResourceConfigFields
+	NullHandling
+	DataQuality
+	Type      ColumnType    `json:"type" yaml:"type"`
+	Required  bool          `json:"required" yaml:"required"`
+	Values    []string      `json:"values" yaml:"values"`
+	MaxLength *int64        `json:"max_length" yaml:"max_length"`
+	Compute   *SparkCompute `json:"compute" yaml:"compute"`
+	Tags      Tags          `json:"tags" yaml:"tags"`
+}
+
+var rawStringListColumnFieldValidations = append([]*cr.StructFieldValidation{
+	{
+		Key:         "name",
+		StructField: "Name",
+		StringValidation: &cr.StringValidation{
 			Required:                   true,
+			AlphaNumericDashUnderscore: true,
 		},
 	},
 	{
@@ -176,10 +503,17 @@ var rawStringColumnFieldValidations = []*cr.StructFieldValidation{
 		StructField:          "Values",
 		StringListValidation: &cr.StringListValidation{},
 	},
+	{
+		Key:         "max_length",
+		StructField: "MaxLength",
+		Int64PtrValidation: &cr.Int64PtrValidation{
+			GreaterThan: pointer.Int64(0),
+		},
+	},
 	sparkComputeFieldValidation("Compute"),
 	tagsFieldValidation,
 	typeFieldValidation,
-}
+}, sharedRawColumnFieldValidations...)
 
 func (rawColumns RawColumns) Validate() error {
 	resources := make([]Resource, len(rawColumns))
@@ -192,6 +526,18 @@ func (rawColumns RawColumns) Validate() error {
 		return ErrorDuplicateResourceName(dups...)
 	}
 
+	for _, rawColumn := range rawColumns {
+		if err := rawColumn.GetNullHandling().Validate(rawColumn.GetType()); err != nil {
+			return errors.Wrap(err, Identify(rawColumn))
+		}
+
+		if stringColumn, ok := rawColumn.(*RawStringColumn); ok {
+			if err := stringColumn.Validate(); err != nil {
+				return errors.Wrap(err, Identify(rawColumn))
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -224,6 +570,30 @@ func (column *RawStringColumn) GetType() ColumnType {
 	return column.Type
 }
 
+func (column *RawBoolColumn) GetType() ColumnType {
+	return column.Type
+}
+
+func (column *RawTimestampColumn) GetType() ColumnType {
+	return column.Type
+}
+
+func (column *RawDateColumn) GetType() ColumnType {
+	return column.Type
+}
+
+func (column *RawIntListColumn) GetType() ColumnType {
+	return column.Type
+}
+
+func (column *RawFloatListColumn) GetType() ColumnType {
+	return column.Type
+}
+
+func (column *RawStringListColumn) GetType() ColumnType {
+	return column.Type
+}
+
 func (column *RawIntColumn) GetCompute() *SparkCompute {
 	return column.Compute
 }
@@ -236,6 +606,30 @@ func (column *RawStringColumn) GetCompute() *SparkCompute {
 	return column.Compute
 }
 
+func (column *RawBoolColumn) GetCompute() *SparkCompute {
+	return column.Compute
+}
+
+func (column *RawTimestampColumn) GetCompute() *SparkCompute {
+	return column.Compute
+}
+
+func (column *RawDateColumn) GetCompute() *SparkCompute {
+	return column.Compute
+}
+
+func (column *RawIntListColumn) GetCompute() *SparkCompute {
+	return column.Compute
+}
+
+func (column *RawFloatListColumn) GetCompute() *SparkCompute {
+	return column.Compute
+}
+
+func (column *RawStringListColumn) GetCompute() *SparkCompute {
+	return column.Compute
+}
+
 func (column *RawIntColumn) GetResourceType() resource.Type {
 	return resource.RawColumnType
 }
@@ -248,6 +642,30 @@ func (column *RawStringColumn) GetResourceType() resource.Type {
 	return resource.RawColumnType
 }
 
+func (column *RawBoolColumn) GetResourceType() resource.Type {
+	return resource.RawColumnType
+}
+
+func (column *RawTimestampColumn) GetResourceType() resource.Type {
+	return resource.RawColumnType
+}
+
+func (column *RawDateColumn) GetResourceType() resource.Type {
+	return resource.RawColumnType
+}
+
+func (column *RawIntListColumn) GetResourceType() resource.Type {
+	return resource.RawColumnType
+}
+
+func (column *RawFloatListColumn) GetResourceType() resource.Type {
+	return resource.RawColumnType
+}
+
+func (column *RawStringListColumn) GetResourceType() resource.Type {
+	return resource.RawColumnType
+}
+
 func (column *RawIntColumn) IsRaw() bool {
 	return true
 }
@@ -260,6 +678,30 @@ func (column *RawStringColumn) IsRaw() bool {
 	return true
 }
 
+func (column *RawBoolColumn) IsRaw() bool {
+	return true
+}
+
+func (column *RawTimestampColumn) IsRaw() bool {
+	return true
+}
+
+func (column *RawDateColumn) IsRaw() bool {
+	return true
+}
+
+func (column *RawIntListColumn) IsRaw() bool {
+	return true
+}
+
+func (column *RawFloatListColumn) IsRaw() bool {
+	return true
+}
+
+func (column *RawStringListColumn) IsRaw() bool {
+	return true
+}
+
 func (column *RawIntColumn) GetUserConfig() Resource {
 	return column
 }
@@ -271,3 +713,27 @@ func (column *RawFloatColumn) GetUserConfig() Resource {
 func (column *RawStringColumn) GetUserConfig() Resource {
 	return column
 }
+
+func (column *RawBoolColumn) GetUserConfig() Resource {
+	return column
+}
+
+func (column *RawTimestampColumn) GetUserConfig() Resource {
+	return column
+}
+
+func (column *RawDateColumn) GetUserConfig() Resource {
+	return column
+}
+
+func (column *RawIntListColumn) GetUserConfig() Resource {
+	return column
+}
+
+func (column *RawFloatListColumn) GetUserConfig() Resource {
+	return column
+}
+
+func (column *RawStringListColumn) GetUserConfig() Resource {
+	return column
+}
@@ -30,6 +30,7 @@ type TransformedColumn struct {
 	Transformer string        `json:"transformer" yaml:"transformer"`
 	Inputs      *Inputs       `json:"inputs" yaml:"inputs"`
 	Compute     *SparkCompute `json:"compute" yaml:"compute"`
+	Image       *string       `json:"image" yaml:"image"`
 	Tags        Tags          `json:"tags" yaml:"tags"`
 }
 
@@ -51,6 +52,7 @@ var transformedColumnValidation = &configreader.StructValidation{
 		},
 		inputValuesFieldValidation,
 		sparkComputeFieldValidation("Compute"),
+		imageFieldValidation,
 		tagsFieldValidation,
 		typeFieldValidation,
 	},
@@ -18,6 +18,9 @@ package userconfig
 
 import (
 	"strings"
+	"time"
+
+	cron "gopkg.in/robfig/cron.v2"
 
 	"github.com/cortexlabs/cortex/pkg/lib/cast"
 	"github.com/cortexlabs/cortex/pkg/lib/configreader"
@@ -499,3 +502,34 @@ func CheckValueRuntimeTypesMatch(runtimeType interface{}, schemaType interface{}
 
 	return ErrorInvalidValueDataType(schemaType) // unexpected
 }
+
+func ValidateSchedule(schedule *string) (*string, error) {
+	if schedule == nil {
+		return nil, nil
+	}
+	if _, err := cron.Parse(*schedule); err != nil {
+		return nil, ErrorInvalidCronSchedule(*schedule, err)
+	}
+	return schedule, nil
+}
+
+func ValidateDuration(duration *string) (*string, error) {
+	if duration == nil {
+		return nil, nil
+	}
+	parsed, err := time.ParseDuration(*duration)
+	if err != nil || parsed < time.Second {
+		return nil, ErrorInvalidDuration(*duration)
+	}
+	return duration, nil
+}
+
+// DurationSecs returns nil if duration is nil (duration must be valid)
+func DurationSecs(duration *string) *int64 {
+	if duration == nil {
+		return nil
+	}
+	parsed, _ := time.ParseDuration(*duration)
+	secs := int64(parsed.Seconds())
+	return &secs
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/cortexlabs/cortex/pkg/lib/cast"
 	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
 )
 
 func TestValidateColumnInputTypes(t *testing.T) {
@@ -963,3 +964,57 @@ func TestCheckValueRuntimeTypesMatch(t *testing.T) {
      `)
 	require.Error(t, CheckValueRuntimeTypesMatch(runtimeType, schemaType))
 }
+
+func TestValidateSchedule(t *testing.T) {
+	var err error
+
+	_, err = ValidateSchedule(nil)
+	require.NoError(t, err)
+
+	_, err = ValidateSchedule(pointer.String("0 0 * * *"))
+	require.NoError(t, err)
+
+	_, err = ValidateSchedule(pointer.String("0 30 2 * * MON-FRI"))
+	require.NoError(t, err)
+
+	_, err = ValidateSchedule(pointer.String("@daily"))
+	require.NoError(t, err)
+
+	_, err = ValidateSchedule(pointer.String("@every 6h"))
+	require.NoError(t, err)
+
+	_, err = ValidateSchedule(pointer.String("0 0 *"))
+	require.Error(t, err)
+
+	_, err = ValidateSchedule(pointer.String("61 * * * *"))
+	require.Error(t, err)
+
+	_, err = ValidateSchedule(pointer.String("@sometimes"))
+	require.Error(t, err)
+}
+
+func TestValidateDuration(t *testing.T) {
+	var err error
+
+	_, err = ValidateDuration(nil)
+	require.NoError(t, err)
+
+	_, err = ValidateDuration(pointer.String("90m"))
+	require.NoError(t, err)
+	require.Equal(t, int64(5400), *DurationSecs(pointer.String("90m")))
+
+	_, err = ValidateDuration(pointer.String("1h30m"))
+	require.NoError(t, err)
+	require.Equal(t, int64(5400), *DurationSecs(pointer.String("1h30m")))
+
+	require.Nil(t, DurationSecs(nil))
+
+	_, err = ValidateDuration(pointer.String("12"))
+	require.Error(t, err)
+
+	_, err = ValidateDuration(pointer.String("-1h"))
+	require.Error(t, err)
+
+	_, err = ValidateDuration(pointer.String("500ms"))
+	require.Error(t, err)
+}
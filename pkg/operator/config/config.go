@@ -18,6 +18,7 @@ package config
 
 import (
 	"path/filepath"
+	"strings"
 
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/argo"
@@ -25,6 +26,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/configreader"
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
 	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/slices"
 	"github.com/cortexlabs/cortex/pkg/lib/spark"
 	"github.com/cortexlabs/cortex/pkg/lib/telemetry"
 )
@@ -39,23 +41,25 @@ var (
 )
 
 type CortexConfig struct {
-	ID                  string `json:"id"`
-	APIVersion          string `json:"api_version"`
-	Bucket              string `json:"bucket"`
-	LogGroup            string `json:"log_group"`
-	Region              string `json:"region"`
-	Namespace           string `json:"namespace"`
-	OperatorImage       string `json:"operator_image"`
-	SparkImage          string `json:"spark_image"`
-	TFTrainImage        string `json:"tf_train_image"`
-	TFServeImage        string `json:"tf_serve_image"`
-	TFAPIImage          string `json:"tf_api_image"`
-	PythonPackagerImage string `json:"python_packager_image"`
-	TFTrainImageGPU     string `json:"tf_train_image_gpu"`
-	TFServeImageGPU     string `json:"tf_serve_image_gpu"`
-	TelemetryURL        string `json:"telemetry_url"`
-	EnableTelemetry     bool   `json:"enable_telemetry"`
-	OperatorInCluster   bool   `json:"operator_in_cluster"`
+	ID                  string                `json:"id"`
+	APIVersion          string                `json:"api_version"`
+	Bucket              string                `json:"bucket"`
+	LogGroup            string                `json:"log_group"`
+	Region              string                `json:"region"`
+	Namespace           string                `json:"namespace"`
+	OperatorImage       string                `json:"operator_image"`
+	SparkImage          string                `json:"spark_image"`
+	TFTrainImage        string                `json:"tf_train_image"`
+	TFServeImage        string                `json:"tf_serve_image"`
+	TFAPIImage          string                `json:"tf_api_image"`
+	PythonPackagerImage string                `json:"python_packager_image"`
+	TFTrainImageGPU     string                `json:"tf_train_image_gpu"`
+	TFServeImageGPU     string                `json:"tf_serve_image_gpu"`
+	Frameworks          map[string]*Framework `json:"frameworks"`
+	ImageRegistries     []string              `json:"image_registries"`
+	TelemetryURL        string                `json:"telemetry_url"`
+	EnableTelemetry     bool                  `json:"enable_telemetry"`
+	OperatorInCluster   bool                  `json:"operator_in_cluster"`
 }
 
 func Init() error {
@@ -73,16 +77,21 @@ func Init() error {
 		PythonPackagerImage: getStr("IMAGE_PYTHON_PACKAGER"),
 		TFTrainImageGPU:     getStr("IMAGE_TF_TRAIN_GPU"),
 		TFServeImageGPU:     getStr("IMAGE_TF_SERVE_GPU"),
+		ImageRegistries:     getOptionalStrList("IMAGE_REGISTRIES"),
 		TelemetryURL:        configreader.MustStringFromEnv("CONST_TELEMETRY_URL", &configreader.StringValidation{Required: false, Default: consts.TelemetryURL}),
 		EnableTelemetry:     getBool("ENABLE_TELEMETRY"),
 		OperatorInCluster:   configreader.MustBoolFromEnv("CONST_OPERATOR_IN_CLUSTER", &configreader.BoolValidation{Default: true}),
 	}
 	Cortex.ID = hash.String(Cortex.Bucket + Cortex.Region + Cortex.LogGroup)
 
+	var err error
+	if Cortex.Frameworks, err = getFrameworks(); err != nil {
+		return err
+	}
+
 	AWS = aws.New(Cortex.Region, Cortex.Bucket)
 	Telemetry = telemetry.New(Cortex.TelemetryURL, AWS.HashedAccountID, Cortex.EnableTelemetry)
 
-	var err error
 	if Kubernetes, err = k8s.New(Cortex.Namespace, Cortex.OperatorInCluster); err != nil {
 		return err
 	}
@@ -108,6 +117,21 @@ func getStr(name string) string {
 	return configreader.MustStringFromEnvOrFile(envVarName, filePath, v)
 }
 
+func getOptionalStr(name string) string {
+	envVarName, filePath := getPaths(name)
+	v := &configreader.StringValidation{Default: "", AllowEmpty: true}
+	return configreader.MustStringFromEnvOrFile(envVarName, filePath, v)
+}
+
+// getOptionalStrList reads a comma-separated list
+func getOptionalStrList(name string) []string {
+	strs := strings.Split(getOptionalStr(name), ",")
+	for i, str := range strs {
+		strs[i] = strings.TrimSpace(str)
+	}
+	return slices.RemoveEmptiesAndUnique(strs)
+}
+
 func getBool(name string) bool {
 	envVarName, filePath := getPaths(name)
 	v := &configreader.BoolValidation{Default: false}
@@ -0,0 +1,67 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/json"
+)
+
+// Framework is a pair of training and serving images. Training images receive the same arguments as the
+// TensorFlow training image, and ServeArgs may reference $(PORT) and $(MODEL_DIR).
+type Framework struct {
+	TrainImage    string   `json:"train_image"`
+	TrainImageGPU string   `json:"train_image_gpu"` // empty if the framework doesn't support GPUs for training
+	ServeImage    string   `json:"serve_image"`
+	ServeImageGPU string   `json:"serve_image_gpu"` // empty if the framework doesn't support GPUs for serving
+	ServeArgs     []string `json:"serve_args"`
+}
+
+// getFrameworks returns the built-in TensorFlow framework, and the frameworks registered in FRAMEWORKS
+// (a JSON object of framework names to Frameworks)
+func getFrameworks() (map[string]*Framework, error) {
+	frameworks := map[string]*Framework{}
+
+	if frameworksStr := getOptionalStr("FRAMEWORKS"); frameworksStr != "" {
+		if err := json.Unmarshal([]byte(frameworksStr), &frameworks); err != nil {
+			return nil, errors.Wrap(err, "FRAMEWORKS")
+		}
+	}
+
+	for name, framework := range frameworks {
+		if name == consts.TensorFlowFramework {
+			return nil, errors.New("FRAMEWORKS", name, "the tensorflow framework is built in and cannot be registered")
+		}
+		if framework == nil || framework.TrainImage == "" || framework.ServeImage == "" {
+			return nil, errors.New("FRAMEWORKS", name, "train_image and serve_image must be specified")
+		}
+	}
+
+	frameworks[consts.TensorFlowFramework] = &Framework{
+		TrainImage:    Cortex.TFTrainImage,
+		TrainImageGPU: Cortex.TFTrainImageGPU,
+		ServeImage:    Cortex.TFServeImage,
+		ServeImageGPU: Cortex.TFServeImageGPU,
+		ServeArgs: []string{
+			"--port=$(PORT)",
+			"--model_base_path=$(MODEL_DIR)",
+		},
+	}
+
+	return frameworks, nil
+}
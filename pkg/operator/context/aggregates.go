@@ -54,6 +54,11 @@ func getAggregates(
 			return nil, errors.WithStack(err)
 		}
 
+		err = validateImage(aggregateConfig.Image)
+		if err != nil {
+			return nil, errors.Wrap(err, userconfig.Identify(aggregateConfig), userconfig.ImageKey)
+		}
+
 		constantIDMap := make(map[string]string, len(aggregateConfig.Inputs.Args))
 		constantIDWithTagsMap := make(map[string]string, len(aggregateConfig.Inputs.Args))
 		for argName, constantName := range aggregateConfig.Inputs.Args {
@@ -71,12 +76,14 @@ func getAggregates(
 		buf.WriteString(rawColumns.ColumnInputsID(aggregateConfig.Inputs.Columns))
 		buf.WriteString(s.Obj(constantIDMap))
 		buf.WriteString(aggregator.ID)
+		buf.WriteString(imageID(aggregateConfig.Image))
 		id := hash.Bytes(buf.Bytes())
 
 		buf.Reset()
 		buf.WriteString(rawColumns.ColumnInputsIDWithTags(aggregateConfig.Inputs.Columns))
 		buf.WriteString(s.Obj(constantIDWithTagsMap))
 		buf.WriteString(aggregator.IDWithTags)
+		buf.WriteString(imageID(aggregateConfig.Image))
 		buf.WriteString(aggregateConfig.Tags.ID())
 		idWithTags := hash.Bytes(buf.Bytes())
 
@@ -79,6 +79,7 @@ func newAggregator(
 		},
 		Aggregator: &aggregatorConfig,
 		Namespace:  namespace,
+		ImplID:     implID,
 		ImplKey:    filepath.Join(consts.AggregatorsDir, implID+".py"),
 	}
 	aggregator.Aggregator.Path = ""
@@ -143,5 +144,13 @@ func getAggregators(
 		aggregators[aggregatorName] = aggregator
 	}
 
+	// Balanced class weights are computed with the class distribution aggregator when the training dataset is generated
+	for _, modelConfig := range config.Models {
+		if modelConfig.ClassWeight != nil {
+			aggregators[consts.ClassWeightAggregator] = builtinAggregators[consts.ClassWeightAggregator]
+			break
+		}
+	}
+
 	return aggregators, nil
 }
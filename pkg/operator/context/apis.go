@@ -19,10 +19,12 @@ package context
 import (
 	"bytes"
 
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
 	"github.com/cortexlabs/cortex/pkg/operator/api/context"
 	"github.com/cortexlabs/cortex/pkg/operator/api/resource"
 	"github.com/cortexlabs/cortex/pkg/operator/api/userconfig"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
 )
 
 func getAPIs(config *userconfig.Config,
@@ -33,9 +35,20 @@ func getAPIs(config *userconfig.Config,
 	for _, apiConfig := range config.APIs {
 		model := models[apiConfig.ModelName]
 
+		err := validateAPIFramework(apiConfig, model)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validateImage(apiConfig.Image)
+		if err != nil {
+			return nil, errors.Wrap(err, userconfig.Identify(apiConfig), userconfig.ImageKey)
+		}
+
 		var buf bytes.Buffer
 		buf.WriteString(apiConfig.Name)
 		buf.WriteString(model.ID)
+		buf.WriteString(imageID(apiConfig.Image))
 		id := hash.Bytes(buf.Bytes())
 
 		buf.WriteString(model.IDWithTags)
@@ -56,3 +69,10 @@ func getAPIs(config *userconfig.Config,
 	}
 	return apis, nil
 }
+
+func validateAPIFramework(apiConfig *userconfig.API, model *context.Model) error {
+	if apiConfig.Compute.GPU > 0 && config.Cortex.Frameworks[model.Framework].ServeImageGPU == "" {
+		return errors.Wrap(ErrorFrameworkGPUUnsupported(model.Framework, "serving"), userconfig.Identify(apiConfig), userconfig.ModelNameKey)
+	}
+	return nil
+}
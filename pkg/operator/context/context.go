@@ -118,8 +118,10 @@ func New(
 		ctx.Environment.ID,
 	)
 	ctx.RawDataset = context.RawDataset{
-		Key:         filepath.Join(ctx.Root, consts.RawDataDir, "raw.parquet"),
-		MetadataKey: filepath.Join(ctx.Root, consts.RawDataDir, "metadata.json"),
+		Key:                  filepath.Join(ctx.Root, consts.RawDataDir, "raw.parquet"),
+		MetadataKey:          filepath.Join(ctx.Root, consts.RawDataDir, "metadata.json"),
+		QualityReportsPrefix: filepath.Join(ctx.Root, consts.RawDataDir, "quality_reports"),
+		ProfilesKey:          filepath.Join(ctx.Root, consts.RawDataDir, "profiles.json"),
 	}
 
 	ctx.StatusPrefix = StatusPrefix(ctx.App.Name)
@@ -198,6 +200,12 @@ func New(
 		return nil, err
 	}
 
+	for _, model := range ctx.Models {
+		if !model.IsExternal() {
+			model.Manifest = ctx.NewModelManifest(model)
+		}
+	}
+
 	ctx.ID = calculateID(ctx)
 	ctx.Key = ctxKey(ctx.ID, ctx.App.Name)
 	return ctx, nil
@@ -273,3 +281,32 @@ func WorkloadSpecKey(workloadID string, appName string) string {
 		workloadID,
 	)
 }
+
+func ModelLineageByNameKey(modelName string, appName string) string {
+	return filepath.Join(
+		consts.AppsDir,
+		appName,
+		consts.ModelLineageDir,
+		"names",
+		modelName+".json",
+	)
+}
+
+func ModelLineageByIDKey(modelID string, appName string) string {
+	return filepath.Join(
+		consts.AppsDir,
+		appName,
+		consts.ModelLineageDir,
+		"ids",
+		modelID+".json",
+	)
+}
+
+// ModelRunsPrefix is outside of the app's directory so that the run history is kept when the app is deleted
+func ModelRunsPrefix(modelName string, appName string) string {
+	return filepath.Join(
+		consts.ModelRunsDir,
+		appName,
+		modelName,
+	)
+}
@@ -37,13 +37,30 @@ func dataID(config *userconfig.Config, datasetVersion string) string {
 	buf.WriteString(datasetVersion)
 
 	rawColumnTypeMap := make(map[string]userconfig.ColumnType, len(config.RawColumns))
+	nullHandlingMap := make(map[string]*userconfig.NullHandling)
 	for _, rawColumnConfig := range config.RawColumns {
 		rawColumnTypeMap[rawColumnConfig.GetName()] = rawColumnConfig.GetType()
+		if nullHandling := rawColumnConfig.GetNullHandling(); nullHandling.IsSet() {
+			nullHandlingMap[rawColumnConfig.GetName()] = nullHandling
+		}
 	}
 	buf.WriteString(s.Obj(config.Environment.Limit))
+	if len(config.Environment.Filter) > 0 {
+		buf.WriteString(s.Obj(config.Environment.Filter))
+	}
 	buf.WriteString(s.Obj(rawColumnTypeMap))
+	if len(nullHandlingMap) > 0 {
+		buf.WriteString(s.Obj(nullHandlingMap))
+	}
+
+	buf.WriteString(dataHashStr(config.Environment.Data))
+
+	return hash.Bytes(buf.Bytes())
+}
+
+func dataHashStr(data userconfig.Data) string {
+	var buf bytes.Buffer
 
-	data := config.Environment.Data
 	switch typedData := data.(type) {
 	case *userconfig.CSVData:
 		buf.WriteString(s.Obj(typedData))
@@ -56,7 +73,48 @@ func dataID(config *userconfig.Config, datasetVersion string) string {
 			schemaMap[parqCol.RawColumnName] = parqCol.ParquetColumnName
 		}
 		buf.WriteString(s.Obj(schemaMap))
+	case *userconfig.JSONData:
+		buf.WriteString(typedData.Type.String())
+		buf.WriteString(typedData.Path)
+		buf.WriteString(s.Bool(typedData.DropNull))
+		schemaMap := map[string]string{} // use map to sort keys
+		for _, jsonCol := range typedData.Schema {
+			schemaMap[jsonCol.RawColumnName] = jsonCol.JSONFieldPath
+		}
+		buf.WriteString(s.Obj(schemaMap))
+	case *userconfig.AvroData:
+		buf.WriteString(typedData.Type.String())
+		buf.WriteString(typedData.Path)
+		buf.WriteString(s.Bool(typedData.DropNull))
+		schemaMap := map[string]string{} // use map to sort keys
+		for _, avroCol := range typedData.Schema {
+			schemaMap[avroCol.RawColumnName] = avroCol.AvroFieldPath
+		}
+		buf.WriteString(s.Obj(schemaMap))
+	case *userconfig.JDBCData:
+		buf.WriteString(typedData.Type.String())
+		buf.WriteString(typedData.URL)
+		buf.WriteString(s.Obj(typedData.Table))
+		buf.WriteString(s.Obj(typedData.Query))
+		buf.WriteString(s.Obj(typedData.PartitionColumn))
+		buf.WriteString(s.Obj(typedData.LowerBound))
+		buf.WriteString(s.Obj(typedData.UpperBound))
+		buf.WriteString(s.Obj(typedData.NumPartitions))
+		buf.WriteString(s.Bool(typedData.DropNull))
+		schemaMap := map[string]string{} // use map to sort keys
+		for _, jdbcCol := range typedData.Schema {
+			schemaMap[jdbcCol.RawColumnName] = jdbcCol.JDBCColumnName
+		}
+		buf.WriteString(s.Obj(schemaMap))
+	case *userconfig.JoinData:
+		buf.WriteString(typedData.Type.String())
+		for _, source := range typedData.Sources {
+			buf.WriteString(source.Name)
+			buf.WriteString(dataHashStr(source.Data))
+		}
+		buf.WriteString(s.Obj(typedData.Joins))
+		buf.WriteString(s.Bool(typedData.DropNull))
 	}
 
-	return hash.Bytes(buf.Bytes())
+	return buf.String()
 }
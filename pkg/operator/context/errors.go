@@ -18,6 +18,8 @@ package context
 
 import (
 	"fmt"
+
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
 )
 
 type ErrorKind int
@@ -25,14 +27,26 @@ type ErrorKind int
 const (
 	ErrUnknown ErrorKind = iota
 	ErrImplDoesNotExist
+	ErrWarmStartModelNotFound
+	ErrUnknownFramework
+	ErrFrameworkGPUUnsupported
+	ErrCustomImagesDisabled
+	ErrImageRegistryNotAllowed
+	ErrIncompatibleImage
 )
 
 var errorKinds = []string{
 	"err_unknown",
 	"err_impl_does_not_exist",
+	"err_warm_start_model_not_found",
+	"err_unknown_framework",
+	"err_framework_gpu_unsupported",
+	"err_custom_images_disabled",
+	"err_image_registry_not_allowed",
+	"err_incompatible_image",
 }
 
-var _ = [1]int{}[int(ErrImplDoesNotExist)-(len(errorKinds)-1)] // Ensure list length matches
+var _ = [1]int{}[int(ErrIncompatibleImage)-(len(errorKinds)-1)] // Ensure list length matches
 
 func (t ErrorKind) String() string {
 	return errorKinds[t]
@@ -83,3 +97,45 @@ func ErrorImplDoesNotExist(path string) error {
 		message: fmt.Sprintf("%s: implementation file does not exist", path),
 	}
 }
+
+func ErrorWarmStartModelNotFound(nameOrID string) error {
+	return Error{
+		Kind:    ErrWarmStartModelNotFound,
+		message: fmt.Sprintf("no trained model with name or ID %s was found to warm-start from", s.UserStr(nameOrID)),
+	}
+}
+
+func ErrorUnknownFramework(framework string, frameworks []string) error {
+	return Error{
+		Kind:    ErrUnknownFramework,
+		message: fmt.Sprintf("%s is not a registered framework (registered frameworks: %s)", s.UserStr(framework), s.UserStrsAnd(frameworks)),
+	}
+}
+
+func ErrorFrameworkGPUUnsupported(framework string, workload string) error {
+	return Error{
+		Kind:    ErrFrameworkGPUUnsupported,
+		message: fmt.Sprintf("the %s framework does not support GPUs for %s", framework, workload),
+	}
+}
+
+func ErrorCustomImagesDisabled() error {
+	return Error{
+		Kind:    ErrCustomImagesDisabled,
+		message: "custom images are not enabled on this cluster (set CORTEX_IMAGE_REGISTRIES when installing Cortex to allow them)",
+	}
+}
+
+func ErrorImageRegistryNotAllowed(image string, registries []string) error {
+	return Error{
+		Kind:    ErrImageRegistryNotAllowed,
+		message: fmt.Sprintf("%s is not in an allowed registry (allowed registries: %s)", s.UserStr(image), s.UserStrsOr(registries)),
+	}
+}
+
+func ErrorIncompatibleImage(dependencyName string, dependencyImage string) error {
+	return Error{
+		Kind:    ErrIncompatibleImage,
+		message: fmt.Sprintf("%s uses image %s; resources which depend on a resource with a custom image must use the same image", dependencyName, s.UserStr(dependencyImage)),
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/operator/api/context"
+	"github.com/cortexlabs/cortex/pkg/operator/api/userconfig"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+)
+
+// validateImage checks that a resource's image override is in one of the operator's allowed registries
+func validateImage(image *string) error {
+	if image == nil {
+		return nil
+	}
+
+	if len(config.Cortex.ImageRegistries) == 0 {
+		return ErrorCustomImagesDisabled()
+	}
+
+	for _, registry := range config.Cortex.ImageRegistries {
+		if strings.HasPrefix(*image, strings.TrimSuffix(registry, "/")+"/") {
+			return nil
+		}
+	}
+	return ErrorImageRegistryNotAllowed(*image, config.Cortex.ImageRegistries)
+}
+
+// validateImageDependency checks that a Spark resource can run in the same job as (or after) a resource it depends on:
+// resources with an image override may only depend on resources which use the default image or the same image
+func validateImageDependency(image *string, dependencyName string, dependencyImage *string) error {
+	if dependencyImage == nil {
+		return nil
+	}
+	if image != nil && *image == *dependencyImage {
+		return nil
+	}
+	return ErrorIncompatibleImage(dependencyName, *dependencyImage)
+}
+
+// getDatasetImage returns the image of the transformed columns used by a model (nil for the default Spark image)
+func getDatasetImage(modelConfig *userconfig.Model, columns context.Columns) (*string, error) {
+	var image *string
+	for _, columnName := range modelConfig.AllColumnNames() {
+		transformedColumn, ok := columns[columnName].(*context.TransformedColumn)
+		if !ok || transformedColumn.Image == nil {
+			continue
+		}
+		if image != nil && *image != *transformedColumn.Image {
+			return nil, ErrorIncompatibleImage(columnName, *transformedColumn.Image)
+		}
+		image = transformedColumn.Image
+	}
+	return image, nil
+}
+
+func imageID(image *string) string {
+	if image == nil {
+		return ""
+	}
+	return *image
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+)
+
+func requireImageError(t *testing.T, expected ErrorKind, err error, msgAndArgs ...interface{}) {
+	if expected == ErrUnknown {
+		require.NoError(t, err, msgAndArgs...)
+		return
+	}
+	require.Error(t, err, msgAndArgs...)
+	cortexErr, ok := errors.Cause(err).(Error)
+	require.True(t, ok, msgAndArgs...)
+	require.Equal(t, expected, cortexErr.Kind, msgAndArgs...)
+}
+
+func TestValidateImage(t *testing.T) {
+	testCases := []struct {
+		image      *string
+		registries []string
+		expected   ErrorKind
+	}{
+		{nil, nil, ErrUnknown},
+		{nil, []string{"registry.io/team"}, ErrUnknown},
+		{pointer.String("registry.io/team/spark:latest"), nil, ErrCustomImagesDisabled},
+		{pointer.String("registry.io/team/spark:latest"), []string{"registry.io/team"}, ErrUnknown},
+		{pointer.String("registry.io/team/spark:latest"), []string{"registry.io/team/"}, ErrUnknown},
+		{pointer.String("registry.io/team/spark:latest"), []string{"other.io", "registry.io"}, ErrUnknown},
+		{pointer.String("registry.io/team/spark:latest"), []string{"other.io"}, ErrImageRegistryNotAllowed},
+		{pointer.String("registry.io/team-evil/spark:latest"), []string{"registry.io/team"}, ErrImageRegistryNotAllowed},
+		{pointer.String("registry.io.evil/team/spark:latest"), []string{"registry.io"}, ErrImageRegistryNotAllowed},
+		{pointer.String("registry.io"), []string{"registry.io"}, ErrImageRegistryNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		config.Cortex = &config.CortexConfig{ImageRegistries: tc.registries}
+		image := "<nil>"
+		if tc.image != nil {
+			image = *tc.image
+		}
+		requireImageError(t, tc.expected, validateImage(tc.image), image, tc.registries)
+	}
+}
+
+func TestValidateImageDependency(t *testing.T) {
+	testCases := []struct {
+		image           *string
+		dependencyImage *string
+		expected        ErrorKind
+	}{
+		{nil, nil, ErrUnknown},
+		{pointer.String("registry.io/team/spark"), nil, ErrUnknown},
+		{pointer.String("registry.io/team/spark"), pointer.String("registry.io/team/spark"), ErrUnknown},
+		{nil, pointer.String("registry.io/team/spark"), ErrIncompatibleImage},
+		{pointer.String("registry.io/team/spark"), pointer.String("registry.io/team/spark:v2"), ErrIncompatibleImage},
+	}
+
+	for i, tc := range testCases {
+		requireImageError(t, tc.expected, validateImageDependency(tc.image, "dependency", tc.dependencyImage), i)
+	}
+}
@@ -19,9 +19,11 @@ package context
 import (
 	"bytes"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/aws"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
@@ -46,28 +48,56 @@ func getModels(
 	models := context.Models{}
 
 	for _, modelConfig := range config.Models {
+		if modelConfig.IsExternal() {
+			model, err := getExternalModel(modelConfig, columns, root)
+			if err != nil {
+				return nil, err
+			}
+			models[modelConfig.Name] = model
+			continue
+		}
+
 		modelImplID, modelImplKey, err := getModelImplID(modelConfig.Path, impls)
 		if err != nil {
 			return nil, errors.Wrap(err, userconfig.Identify(modelConfig), userconfig.PathKey)
 		}
 
-		targetDataType := columns[modelConfig.TargetColumn].GetType()
-		err = context.ValidateModelTargetType(targetDataType, modelConfig.Type)
+		err = validateModelTargets(modelConfig, columns)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validateModelFramework(modelConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validateImage(modelConfig.Image)
+		if err != nil {
+			return nil, errors.Wrap(err, userconfig.Identify(modelConfig), userconfig.ImageKey)
+		}
+
+		datasetImage, err := getDatasetImage(modelConfig, columns)
 		if err != nil {
 			return nil, errors.Wrap(err, userconfig.Identify(modelConfig))
 		}
 
 		var buf bytes.Buffer
 		buf.WriteString(modelConfig.Type.String())
+		buf.WriteString(modelConfig.Framework)
+		buf.WriteString(imageID(modelConfig.Image))
 		buf.WriteString(modelImplID)
 		for _, pythonPackage := range pythonPackages {
 			buf.WriteString(pythonPackage.GetID())
 		}
 		buf.WriteString(modelConfig.PredictionKey)
+		buf.WriteString(s.Obj(modelConfig.Targets))
 		buf.WriteString(s.Obj(modelConfig.Hparams))
 		buf.WriteString(s.Obj(modelConfig.DataPartitionRatio))
 		buf.WriteString(s.Obj(modelConfig.Training))
 		buf.WriteString(s.Obj(modelConfig.Evaluation))
+		buf.WriteString(modelConfig.WeightColumn)
+		buf.WriteString(s.Obj(modelConfig.ClassWeight))
 		buf.WriteString(columns.IDWithTags(modelConfig.AllColumnNames())) // A change in tags can invalidate the model
 
 		for _, aggregate := range modelConfig.Aggregates {
@@ -76,9 +106,22 @@ func getModels(
 		buf.WriteString(modelConfig.Tags.ID())
 
 		modelID := hash.Bytes(buf.Bytes())
+		baseID := modelID
+
+		var warmStartLineage *context.ModelLineage
+		if modelConfig.WarmStart != nil {
+			warmStartLineage, err = resolveWarmStart(*modelConfig.WarmStart, baseID, config.App.Name)
+			if err != nil {
+				return nil, errors.Wrap(err, userconfig.Identify(modelConfig), userconfig.WarmStartKey)
+			}
+			buf.WriteString(warmStartLineage.ModelID)
+			modelID = hash.Bytes(buf.Bytes())
+		}
 
 		buf.Reset()
 		buf.WriteString(s.Obj(modelConfig.DataPartitionRatio))
+		buf.WriteString(s.Int(modelConfig.NumFolds()))
+		buf.WriteString(s.Obj(modelConfig.ClassWeight)) // class weights are computed with the dataset
 		buf.WriteString(columns.ID(modelConfig.AllColumnNames()))
 		datasetID := hash.Bytes(buf.Bytes())
 		buf.WriteString(columns.IDWithTags(modelConfig.AllColumnNames()))
@@ -86,11 +129,29 @@ func getModels(
 
 		datasetRoot := filepath.Join(root, consts.TrainingDataDir, datasetID)
 
+		var foldKeys []string
+		var foldMetricsKeys []string
+		for i := 0; i < modelConfig.NumFolds(); i++ {
+			foldKeys = append(foldKeys, filepath.Join(datasetRoot, "folds", s.Int(i)+".tfrecord"))
+			foldMetricsKeys = append(foldMetricsKeys, filepath.Join(root, consts.ModelsDir, modelID, "folds", s.Int(i), "metrics.json"))
+		}
+
 		trainingDatasetName := strings.Join([]string{
 			modelConfig.Name,
 			resource.TrainingDatasetType.String(),
 		}, "/")
 
+		lineage := &context.ModelLineage{
+			ModelID:       modelID,
+			ModelName:     modelConfig.Name,
+			BaseID:        baseID,
+			CheckpointKey: filepath.Join(root, consts.ModelsDir, modelID, "checkpoint.zip"),
+		}
+		if warmStartLineage != nil {
+			lineage.WarmStartModelID = warmStartLineage.ModelID
+			lineage.WarmStartCheckpointKey = warmStartLineage.CheckpointKey
+		}
+
 		models[modelConfig.Name] = &context.Model{
 			ComputedResourceFields: &context.ComputedResourceFields{
 				ResourceFields: &context.ResourceFields{
@@ -99,10 +160,21 @@ func getModels(
 					ResourceType: resource.ModelType,
 				},
 			},
-			Model:   modelConfig,
-			Key:     filepath.Join(root, consts.ModelsDir, modelID+".zip"),
-			ImplID:  modelImplID,
-			ImplKey: modelImplKey,
+			Model:           modelConfig,
+			Key:             filepath.Join(root, consts.ModelsDir, modelID+".zip"),
+			CheckpointKey:   lineage.CheckpointKey,
+			SummariesDir:    filepath.Join(root, consts.ModelsDir, modelID, "summaries"),
+			ImplID:          modelImplID,
+			ImplKey:         modelImplKey,
+			FoldMetricsKeys: foldMetricsKeys,
+			ImportanceKey:   filepath.Join(root, consts.ModelsDir, modelID, "feature_importance.json"),
+			Lineage:         lineage,
+			LineageKeys: []string{
+				ModelLineageByNameKey(modelConfig.Name, config.App.Name),
+				ModelLineageByIDKey(modelID, config.App.Name),
+			},
+			RunsPrefix:  ModelRunsPrefix(modelConfig.Name, config.App.Name),
+			ManifestKey: filepath.Join(root, consts.ModelsDir, modelID, "manifest.json"),
 			Dataset: &context.TrainingDataset{
 				ResourceConfigFields: userconfig.ResourceConfigFields{
 					Name:     trainingDatasetName,
@@ -117,8 +189,10 @@ func getModels(
 					},
 				},
 				ModelName:   modelConfig.Name,
+				Image:       datasetImage,
 				TrainKey:    filepath.Join(datasetRoot, "train.tfrecord"),
 				EvalKey:     filepath.Join(datasetRoot, "eval.tfrecord"),
+				FoldKeys:    foldKeys,
 				MetadataKey: filepath.Join(datasetRoot, "metadata.json"),
 			},
 		}
@@ -127,6 +201,133 @@ func getModels(
 	return models, nil
 }
 
+func getExternalModel(
+	modelConfig *userconfig.Model,
+	columns context.Columns,
+	root string,
+) (*context.Model, error) {
+
+	err := validateModelTargets(modelConfig, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateModelFramework(modelConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(modelConfig.Type.String())
+	buf.WriteString(modelConfig.Framework)
+	buf.WriteString(modelConfig.ExternalModel.Path)
+	buf.WriteString(modelConfig.PredictionKey)
+	buf.WriteString(s.Obj(modelConfig.Targets))
+	buf.WriteString(columns.IDWithTags(modelConfig.AllColumnNames()))
+	buf.WriteString(modelConfig.Tags.ID())
+	modelID := hash.Bytes(buf.Bytes())
+
+	return &context.Model{
+		ComputedResourceFields: &context.ComputedResourceFields{
+			ResourceFields: &context.ResourceFields{
+				ID:           modelID,
+				IDWithTags:   modelID,
+				ResourceType: resource.ModelType,
+			},
+		},
+		Model:         modelConfig,
+		Key:           filepath.Join(root, consts.ModelsDir, modelID+".zip"),
+		CheckpointKey: filepath.Join(root, consts.ModelsDir, modelID, "checkpoint.zip"),
+	}, nil
+}
+
+// resolveWarmStart finds the trained model to warm-start from, by name or by ID
+func resolveWarmStart(nameOrID string, baseID string, appName string) (*context.ModelLineage, error) {
+	lineage, err := readModelLineage(ModelLineageByNameKey(nameOrID, appName))
+	if err != nil {
+		return nil, err
+	}
+
+	// If this model was already trained, keep warm-starting from the same source so that its ID doesn't change
+	if lineage != nil && lineage.BaseID == baseID && lineage.WarmStartModelID != "" {
+		nameOrID = lineage.WarmStartModelID
+		lineage = nil
+	}
+
+	if lineage == nil {
+		lineage, err = readModelLineage(ModelLineageByIDKey(nameOrID, appName))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if lineage == nil {
+		return nil, ErrorWarmStartModelNotFound(nameOrID)
+	}
+	return lineage, nil
+}
+
+func readModelLineage(key string) (*context.ModelLineage, error) {
+	var lineage context.ModelLineage
+	err := config.AWS.ReadJSONFromS3(&lineage, key)
+	if aws.IsNoSuchKeyErr(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "read model lineage")
+	}
+	return &lineage, nil
+}
+
+func validateModelTargets(modelConfig *userconfig.Model, columns context.Columns) error {
+	if modelConfig.TargetColumn != "" {
+		targetDataType := columns[modelConfig.TargetColumn].GetType()
+		err := context.ValidateModelTargetType(targetDataType, modelConfig.Type)
+		if err != nil {
+			return errors.Wrap(err, userconfig.Identify(modelConfig))
+		}
+	}
+
+	for i, target := range modelConfig.Targets {
+		targetDataType := columns[target.Column].GetType()
+		err := context.ValidateModelTargetType(targetDataType, target.Type)
+		if err != nil {
+			return errors.Wrap(err, userconfig.Identify(modelConfig), userconfig.TargetsKey, s.Index(i))
+		}
+	}
+
+	if modelConfig.WeightColumn != "" {
+		weightDataType := columns[modelConfig.WeightColumn].GetType()
+		if weightDataType != userconfig.IntegerColumnType && weightDataType != userconfig.FloatColumnType {
+			return errors.Wrap(userconfig.ErrorWeightColumnType(modelConfig.WeightColumn, weightDataType), userconfig.Identify(modelConfig), userconfig.WeightColumnKey)
+		}
+	}
+
+	return nil
+}
+
+func validateModelFramework(modelConfig *userconfig.Model) error {
+	framework, ok := config.Cortex.Frameworks[modelConfig.Framework]
+	if !ok {
+		return errors.Wrap(ErrorUnknownFramework(modelConfig.Framework, registeredFrameworks()), userconfig.Identify(modelConfig), userconfig.FrameworkKey)
+	}
+
+	if !modelConfig.IsExternal() && modelConfig.Compute != nil && modelConfig.Compute.GPU != nil && *modelConfig.Compute.GPU > 0 && framework.TrainImageGPU == "" {
+		return errors.Wrap(ErrorFrameworkGPUUnsupported(modelConfig.Framework, "training"), userconfig.Identify(modelConfig), userconfig.FrameworkKey)
+	}
+
+	return nil
+}
+
+func registeredFrameworks() []string {
+	frameworks := make([]string, 0, len(config.Cortex.Frameworks))
+	for name := range config.Cortex.Frameworks {
+		frameworks = append(frameworks, name)
+	}
+	sort.Strings(frameworks)
+	return frameworks
+}
+
 func getModelImplID(implPath string, impls map[string][]byte) (string, string, error) {
 	impl, ok := impls[implPath]
 	if !ok {
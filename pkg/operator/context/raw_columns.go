@@ -41,6 +41,12 @@ func getRawColumns(
 		buf.WriteString(env.ID)
 		buf.WriteString(columnConfig.GetName())
 		buf.WriteString(columnConfig.GetType().String())
+		if nullHandling := columnConfig.GetNullHandling(); nullHandling.IsSet() {
+			buf.WriteString(s.Obj(nullHandling))
+		}
+		if dataQuality := columnConfig.GetDataQuality(); dataQuality.IsSet() {
+			buf.WriteString(s.Obj(dataQuality))
+		}
 
 		var rawColumn context.RawColumn
 		switch typedColumnConfig := columnConfig.(type) {
@@ -81,6 +87,11 @@ func getRawColumns(
 		case *userconfig.RawStringColumn:
 			buf.WriteString(s.Bool(typedColumnConfig.Required))
 			buf.WriteString(s.Obj(slices.SortStrsCopy(typedColumnConfig.Values)))
+			if typedColumnConfig.Pattern != nil || typedColumnConfig.MinLength != nil || typedColumnConfig.MaxLength != nil {
+				buf.WriteString(s.Obj(typedColumnConfig.Pattern))
+				buf.WriteString(s.Obj(typedColumnConfig.MinLength))
+				buf.WriteString(s.Obj(typedColumnConfig.MaxLength))
+			}
 			id := hash.Bytes(buf.Bytes())
 			idWithTags := hash.String(id + typedColumnConfig.Tags.ID())
 			rawColumn = &context.RawStringColumn{
@@ -93,8 +104,102 @@ func getRawColumns(
 				},
 				RawStringColumn: typedColumnConfig,
 			}
+		case *userconfig.RawBoolColumn:
+			buf.WriteString(s.Bool(typedColumnConfig.Required))
+			id := hash.Bytes(buf.Bytes())
+			idWithTags := hash.String(id + typedColumnConfig.Tags.ID())
+			rawColumn = &context.RawBoolColumn{
+				ComputedResourceFields: &context.ComputedResourceFields{
+					ResourceFields: &context.ResourceFields{
+						ID:           id,
+						IDWithTags:   idWithTags,
+						ResourceType: resource.RawColumnType,
+					},
+				},
+				RawBoolColumn: typedColumnConfig,
+			}
+		case *userconfig.RawTimestampColumn:
+			buf.WriteString(s.Bool(typedColumnConfig.Required))
+			id := hash.Bytes(buf.Bytes())
+			idWithTags := hash.String(id + typedColumnConfig.Tags.ID())
+			rawColumn = &context.RawTimestampColumn{
+				ComputedResourceFields: &context.ComputedResourceFields{
+					ResourceFields: &context.ResourceFields{
+						ID:           id,
+						IDWithTags:   idWithTags,
+						ResourceType: resource.RawColumnType,
+					},
+				},
+				RawTimestampColumn: typedColumnConfig,
+			}
+		case *userconfig.RawDateColumn:
+			buf.WriteString(s.Bool(typedColumnConfig.Required))
+			id := hash.Bytes(buf.Bytes())
+			idWithTags := hash.String(id + typedColumnConfig.Tags.ID())
+			rawColumn = &context.RawDateColumn{
+				ComputedResourceFields: &context.ComputedResourceFields{
+					ResourceFields: &context.ResourceFields{
+						ID:           id,
+						IDWithTags:   idWithTags,
+						ResourceType: resource.RawColumnType,
+					},
+				},
+				RawDateColumn: typedColumnConfig,
+			}
+		case *userconfig.RawIntListColumn:
+			buf.WriteString(s.Bool(typedColumnConfig.Required))
+			buf.WriteString(s.Obj(typedColumnConfig.Min))
+			buf.WriteString(s.Obj(typedColumnConfig.Max))
+			buf.WriteString(s.Obj(slices.SortInt64sCopy(typedColumnConfig.Values)))
+			buf.WriteString(s.Obj(typedColumnConfig.MaxLength))
+			id := hash.Bytes(buf.Bytes())
+			idWithTags := hash.String(id + typedColumnConfig.Tags.ID())
+			rawColumn = &context.RawIntListColumn{
+				ComputedResourceFields: &context.ComputedResourceFields{
+					ResourceFields: &context.ResourceFields{
+						ID:           id,
+						IDWithTags:   idWithTags,
+						ResourceType: resource.RawColumnType,
+					},
+				},
+				RawIntListColumn: typedColumnConfig,
+			}
+		case *userconfig.RawFloatListColumn:
+			buf.WriteString(s.Bool(typedColumnConfig.Required))
+			buf.WriteString(s.Obj(typedColumnConfig.Min))
+			buf.WriteString(s.Obj(typedColumnConfig.Max))
+			buf.WriteString(s.Obj(slices.SortFloat32sCopy(typedColumnConfig.Values)))
+			buf.WriteString(s.Obj(typedColumnConfig.MaxLength))
+			id := hash.Bytes(buf.Bytes())
+			idWithTags := hash.String(id + typedColumnConfig.Tags.ID())
+			rawColumn = &context.RawFloatListColumn{
+				ComputedResourceFields: &context.ComputedResourceFields{
+					ResourceFields: &context.ResourceFields{
+						ID:           id,
+						IDWithTags:   idWithTags,
+						ResourceType: resource.RawColumnType,
+					},
+				},
+				RawFloatListColumn: typedColumnConfig,
+			}
+		case *userconfig.RawStringListColumn:
+			buf.WriteString(s.Bool(typedColumnConfig.Required))
+			buf.WriteString(s.Obj(slices.SortStrsCopy(typedColumnConfig.Values)))
+			buf.WriteString(s.Obj(typedColumnConfig.MaxLength))
+			id := hash.Bytes(buf.Bytes())
+			idWithTags := hash.String(id + typedColumnConfig.Tags.ID())
+			rawColumn = &context.RawStringListColumn{
+				ComputedResourceFields: &context.ComputedResourceFields{
+					ResourceFields: &context.ResourceFields{
+						ID:           id,
+						IDWithTags:   idWithTags,
+						ResourceType: resource.RawColumnType,
+					},
+				},
+				RawStringListColumn: typedColumnConfig,
+			}
 		default:
-			return nil, errors.Wrap(configreader.ErrorInvalidStr(userconfig.TypeKey, userconfig.IntegerColumnType.String(), userconfig.FloatColumnType.String(), userconfig.StringColumnType.String()), userconfig.Identify(columnConfig)) // unexpected error
+			return nil, errors.Wrap(configreader.ErrorInvalidStr(userconfig.TypeKey, userconfig.ColumnTypeStrings()...), userconfig.Identify(columnConfig)) // unexpected error
 		}
 
 		rawColumns[columnConfig.GetName()] = rawColumn
@@ -49,6 +49,11 @@ func getTransformedColumns(
 			return nil, errors.WithStack(err)
 		}
 
+		err = validateImage(transformedColumnConfig.Image)
+		if err != nil {
+			return nil, errors.Wrap(err, userconfig.Identify(transformedColumnConfig), userconfig.ImageKey)
+		}
+
 		valueResourceIDMap := make(map[string]string, len(transformedColumnConfig.Inputs.Args))
 		valueResourceIDWithTagsMap := make(map[string]string, len(transformedColumnConfig.Inputs.Args))
 		for argName, resourceName := range transformedColumnConfig.Inputs.Args {
@@ -57,6 +62,12 @@ func getTransformedColumns(
 			if err != nil {
 				return nil, errors.Wrap(err, userconfig.Identify(transformedColumnConfig), userconfig.InputsKey, userconfig.ArgsKey, argName)
 			}
+			if aggregate, ok := resource.(*context.Aggregate); ok {
+				err := validateImageDependency(transformedColumnConfig.Image, aggregate.Name, aggregate.Image)
+				if err != nil {
+					return nil, errors.Wrap(err, userconfig.Identify(transformedColumnConfig), userconfig.InputsKey, userconfig.ArgsKey, argName)
+				}
+			}
 			valueResourceIDMap[argName] = resource.GetID()
 			valueResourceIDWithTagsMap[argName] = resource.GetIDWithTags()
 		}
@@ -65,12 +76,14 @@ func getTransformedColumns(
 		buf.WriteString(rawColumns.ColumnInputsID(transformedColumnConfig.Inputs.Columns))
 		buf.WriteString(s.Obj(valueResourceIDMap))
 		buf.WriteString(transformer.ID)
+		buf.WriteString(imageID(transformedColumnConfig.Image))
 		id := hash.Bytes(buf.Bytes())
 
 		buf.Reset()
 		buf.WriteString(rawColumns.ColumnInputsIDWithTags(transformedColumnConfig.Inputs.Columns))
 		buf.WriteString(s.Obj(valueResourceIDWithTagsMap))
 		buf.WriteString(transformer.IDWithTags)
+		buf.WriteString(imageID(transformedColumnConfig.Image))
 		buf.WriteString(transformedColumnConfig.Tags.ID())
 		idWithTags := hash.Bytes(buf.Bytes())
 
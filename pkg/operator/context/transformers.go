@@ -78,6 +78,7 @@ func newTransformer(
 		},
 		Transformer: &transConfig,
 		Namespace:   namespace,
+		ImplID:      implID,
 		ImplKey:     filepath.Join(consts.TransformersDir, implID+".py"),
 	}
 	transformer.Transformer.Path = ""
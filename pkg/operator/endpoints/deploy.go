@@ -80,6 +80,11 @@ func Deploy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = updateSchedules(r, ctx)
+	if RespondIfError(w, err, ctx.App.Name, "schedule") {
+		return
+	}
+
 	switch {
 	case isRunning && ignoreCache:
 		respondDeploy(w, ResDeploymentStoppedCacheDeletedDeploymentStarted)
@@ -105,6 +110,20 @@ func respondDeploy(w http.ResponseWriter, message string) {
 	Respond(w, response)
 }
 
+func updateSchedules(r *http.Request, ctx *context.Context) error {
+	envName, err := getRequiredQueryParam("environment", r)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	zipBytes, err := files.ReadReqFile(r, "config.zip")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return workloads.UpdateSchedules(ctx, envName, zipBytes)
+}
+
 func getContext(r *http.Request, ignoreCache bool) (*context.Context, error) {
 	envName, err := getRequiredQueryParam("environment", r)
 	if err != nil {
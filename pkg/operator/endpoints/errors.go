@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	s "github.com/cortexlabs/cortex/pkg/lib/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/api/context"
 )
 
 type ErrorKind int
@@ -37,6 +38,8 @@ const (
 	ErrAnyQueryParamRequired
 	ErrAnyPathParamRequired
 	ErrPending
+	ErrInvalidMetricFilter
+	ErrExternalModelManifest
 )
 
 var (
@@ -53,10 +56,12 @@ var (
 		"err_any_query_param_required",
 		"err_any_path_param_required",
 		"err_pending",
+		"err_invalid_metric_filter",
+		"err_external_model_manifest",
 	}
 )
 
-var _ = [1]int{}[int(ErrPending)-(len(errorKinds)-1)] // Ensure list length matches
+var _ = [1]int{}[int(ErrExternalModelManifest)-(len(errorKinds)-1)] // Ensure list length matches
 
 func (t ErrorKind) String() string {
 	return errorKinds[t]
@@ -176,3 +181,21 @@ func ErrorPending() error {
 		message: "pending",
 	}
 }
+
+func ErrorInvalidMetricFilter(filter string) error {
+	var operators []string
+	for _, operator := range context.MetricFilterOperators {
+		operators = append(operators, string(operator))
+	}
+	return Error{
+		Kind:    ErrInvalidMetricFilter,
+		message: fmt.Sprintf("invalid metric filter %s (filters must be a metric name followed by one of %s and a number, e.g. accuracy>=0.9)", s.UserStr(filter), s.UserStrsOr(operators)),
+	}
+}
+
+func ErrorExternalModelManifest(modelName string) error {
+	return Error{
+		Kind:    ErrExternalModelManifest,
+		message: fmt.Sprintf("model %s is an external model, manifests are only saved for models which are trained by cortex", s.UserStr(modelName)),
+	}
+}
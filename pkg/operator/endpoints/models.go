@@ -0,0 +1,214 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/operator/api/context"
+	"github.com/cortexlabs/cortex/pkg/operator/api/resource"
+	schema "github.com/cortexlabs/cortex/pkg/operator/api/schema"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
+)
+
+func GetModelMetrics(w http.ResponseWriter, r *http.Request) {
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+	id, err := getRequiredPathParam("id", r)
+	if RespondIfError(w, err) {
+		return
+	}
+	ctx := workloads.CurrentContext(appName)
+	if ctx == nil {
+		RespondError(w, ErrorAppNotDeployed(appName))
+		return
+	}
+
+	model := ctx.Models.OneByID(id)
+
+	if model == nil {
+		RespondError(w, resource.ErrorNotFound(id, resource.ModelType))
+		return
+	}
+
+	response := schema.GetModelMetricsResponse{}
+
+	if len(model.FoldMetricsKeys) > 0 {
+		var folds []map[string]float64
+		for _, foldMetricsKey := range model.FoldMetricsKeys {
+			exists, err := config.AWS.IsS3File(foldMetricsKey)
+			if RespondIfError(w, err, resource.ModelType.String(), id) {
+				return
+			}
+			if !exists {
+				RespondError(w, errors.Wrap(ErrorPending(), resource.ModelType.String(), id))
+				return
+			}
+
+			var foldMetrics map[string]float64
+			err = config.AWS.ReadJSONFromS3(&foldMetrics, foldMetricsKey)
+			if RespondIfError(w, err, resource.ModelType.String(), id) {
+				return
+			}
+			folds = append(folds, foldMetrics)
+		}
+		response.CrossValidation = resource.NewCrossValidationMetrics(folds)
+	}
+
+	if model.Evaluation.FeatureImportance {
+		exists, err := config.AWS.IsS3File(model.ImportanceKey)
+		if RespondIfError(w, err, resource.ModelType.String(), id) {
+			return
+		}
+		if !exists {
+			RespondError(w, errors.Wrap(ErrorPending(), resource.ModelType.String(), id))
+			return
+		}
+
+		var featureImportance resource.FeatureImportance
+		err = config.AWS.ReadJSONFromS3(&featureImportance, model.ImportanceKey)
+		if RespondIfError(w, err, resource.ModelType.String(), id) {
+			return
+		}
+		response.FeatureImportance = &featureImportance
+	}
+
+	Respond(w, response)
+}
+
+// GetModelManifest returns the manifest saved when the model was trained, with the digests of the images that produced it
+func GetModelManifest(w http.ResponseWriter, r *http.Request) {
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+	id, err := getRequiredPathParam("id", r)
+	if RespondIfError(w, err) {
+		return
+	}
+	ctx := workloads.CurrentContext(appName)
+	if ctx == nil {
+		RespondError(w, ErrorAppNotDeployed(appName))
+		return
+	}
+
+	model := ctx.Models.OneByID(id)
+	if model == nil {
+		RespondError(w, resource.ErrorNotFound(id, resource.ModelType))
+		return
+	}
+	if model.IsExternal() {
+		RespondError(w, ErrorExternalModelManifest(model.Name))
+		return
+	}
+
+	exists, err := config.AWS.IsS3File(model.ManifestKey)
+	if RespondIfError(w, err, resource.ModelType.String(), id) {
+		return
+	}
+	if !exists {
+		RespondError(w, errors.Wrap(ErrorPending(), resource.ModelType.String(), id))
+		return
+	}
+
+	var manifest context.ModelManifest
+	err = config.AWS.ReadJSONFromS3(&manifest, model.ManifestKey)
+	if RespondIfError(w, err, resource.ModelType.String(), id) {
+		return
+	}
+
+	// the digests are saved in the manifest once the operator has seen the training workload succeed
+	if manifest.ImageDigests == nil {
+		manifest.ImageDigests, err = workloads.GetImageDigests(manifest.WorkloadIDs, appName)
+		if RespondIfError(w, err, resource.ModelType.String(), id) {
+			return
+		}
+	}
+
+	Respond(w, schema.GetModelManifestResponse{Manifest: &manifest})
+}
+
+// GetModelRuns returns the model's run history, which is kept even if the model is no longer deployed
+func GetModelRuns(w http.ResponseWriter, r *http.Request) {
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+	modelName, err := getRequiredQueryParam("modelName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	// filters are comma-separated, e.g. "accuracy>=0.9,loss<0.2"
+	var filters []*context.MetricFilter
+	for _, filterStr := range strings.Split(getOptionalQParam("filter", r), ",") {
+		if strings.TrimSpace(filterStr) == "" {
+			continue
+		}
+		filter, err := parseMetricFilter(filterStr)
+		if RespondIfError(w, err) {
+			return
+		}
+		filters = append(filters, filter)
+	}
+
+	keys, err := config.AWS.ListS3Prefix(ocontext.ModelRunsPrefix(modelName, appName))
+	if RespondIfError(w, err, resource.ModelType.String(), modelName) {
+		return
+	}
+
+	runs := context.ModelRuns{}
+	for _, key := range keys {
+		var run context.ModelRun
+		err = config.AWS.ReadJSONFromS3(&run, key)
+		if RespondIfError(w, err, resource.ModelType.String(), modelName) {
+			return
+		}
+		runs = append(runs, &run)
+	}
+
+	runs = runs.Filter(filters...)
+	runs.SortByStart()
+	if metric := getOptionalQParam("sort", r); metric != "" {
+		runs.SortByMetric(metric, !getOptionalBoolQParam("ascending", false, r))
+	}
+
+	Respond(w, schema.GetModelRunsResponse{Runs: runs})
+}
+
+func parseMetricFilter(filterStr string) (*context.MetricFilter, error) {
+	for _, operator := range context.MetricFilterOperators {
+		index := strings.Index(filterStr, string(operator))
+		if index == -1 {
+			continue
+		}
+		metric := strings.TrimSpace(filterStr[:index])
+		value, err := strconv.ParseFloat(strings.TrimSpace(filterStr[index+len(operator):]), 64)
+		if metric == "" || err != nil {
+			return nil, ErrorInvalidMetricFilter(filterStr)
+		}
+		return &context.MetricFilter{Metric: metric, Operator: operator, Value: value}, nil
+	}
+	return nil, ErrorInvalidMetricFilter(filterStr)
+}
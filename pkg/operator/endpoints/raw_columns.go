@@ -0,0 +1,70 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/cortexlabs/cortex/pkg/operator/api/context"
+	"github.com/cortexlabs/cortex/pkg/operator/api/resource"
+	schema "github.com/cortexlabs/cortex/pkg/operator/api/schema"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
+)
+
+// GetRawColumnQualityReport returns the data quality report which was saved when the raw column was validated (nil if there is none)
+func GetRawColumnQualityReport(w http.ResponseWriter, r *http.Request) {
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+	id, err := getRequiredPathParam("id", r)
+	if RespondIfError(w, err) {
+		return
+	}
+	ctx := workloads.CurrentContext(appName)
+	if ctx == nil {
+		RespondError(w, ErrorAppNotDeployed(appName))
+		return
+	}
+
+	rawColumn := ctx.RawColumns.OneByID(id)
+
+	if rawColumn == nil {
+		RespondError(w, resource.ErrorNotFound(id, resource.RawColumnType))
+		return
+	}
+
+	reportKey := ctx.RawDataset.QualityReportKey(id)
+	exists, err := config.AWS.IsS3File(reportKey)
+	if RespondIfError(w, err, resource.RawColumnType.String(), id) {
+		return
+	}
+	if !exists {
+		// the report isn't saved if ingestion failed before the column was validated
+		Respond(w, schema.GetRawColumnQualityReportResponse{Report: nil})
+		return
+	}
+
+	var report context.DataQualityReport
+	err = config.AWS.ReadJSONFromS3(&report, reportKey)
+	if RespondIfError(w, err, resource.RawColumnType.String(), id) {
+		return
+	}
+
+	Respond(w, schema.GetRawColumnQualityReportResponse{Report: &report})
+}
@@ -19,7 +19,10 @@ package endpoints
 import (
 	"net/http"
 
+	"github.com/cortexlabs/cortex/pkg/lib/aws"
+	"github.com/cortexlabs/cortex/pkg/operator/api/context"
 	"github.com/cortexlabs/cortex/pkg/operator/api/schema"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
 	"github.com/cortexlabs/cortex/pkg/operator/workloads"
 )
 
@@ -55,12 +58,27 @@ func GetResources(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// profiling is skipped if it fails, and the profiles of every raw column are saved together (by name)
+	var rawColumnProfiles map[string]*context.RawColumnProfile
+	err = config.AWS.ReadJSONFromS3(&rawColumnProfiles, ctx.RawDataset.ProfilesKey)
+	if err != nil && !aws.IsNoSuchKeyErr(err) {
+		RespondError(w, err)
+		return
+	}
+	for name := range rawColumnProfiles {
+		if _, ok := ctx.RawColumns[name]; !ok {
+			delete(rawColumnProfiles, name)
+		}
+	}
+
 	response := schema.GetResourcesResponse{
-		Context:          ctx,
-		DataStatuses:     dataStatuses,
-		APIStatuses:      apiStatuses,
-		APIGroupStatuses: apiGroupStatuses,
-		APIsBaseURL:      apisBaseURL,
+		Context:           ctx,
+		DataStatuses:      dataStatuses,
+		APIStatuses:       apiStatuses,
+		APIGroupStatuses:  apiGroupStatuses,
+		APIsBaseURL:       apisBaseURL,
+		ScheduleStatuses:  workloads.GetScheduleStatuses(appName),
+		RawColumnProfiles: rawColumnProfiles,
 	}
 
 	Respond(w, response)
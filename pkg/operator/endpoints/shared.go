@@ -39,6 +39,7 @@ const (
 	ResDeploymentStoppedDeploymentStarted             = "Running deployment stopped, new deployment started"
 	ResDeploymentStoppedCacheDeletedDeploymentStarted = "Running deployment stopped, cached deleted, new deployment started"
 	ResDeploymentStoppedDeploymentUpToDate            = "Running deployment stopped, new deployment is up-to-date"
+	ResTensorBoardStopped                             = "TensorBoard stopped"
 )
 
 func Respond(w http.ResponseWriter, response interface{}) {
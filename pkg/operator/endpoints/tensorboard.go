@@ -0,0 +1,76 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/cortexlabs/cortex/pkg/operator/api/resource"
+	"github.com/cortexlabs/cortex/pkg/operator/api/schema"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
+)
+
+func StartTensorBoard(w http.ResponseWriter, r *http.Request) {
+	config.Telemetry.ReportEvent("endpoint.tensorboard.start")
+
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+	modelName, err := getRequiredQueryParam("modelName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	ctx := workloads.CurrentContext(appName)
+	if ctx == nil {
+		RespondError(w, ErrorAppNotDeployed(appName))
+		return
+	}
+	if _, ok := ctx.Models[modelName]; !ok {
+		RespondError(w, resource.ErrorNotFound(modelName, resource.ModelType))
+		return
+	}
+
+	url, err := workloads.StartTensorBoard(ctx, modelName)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	Respond(w, schema.StartTensorBoardResponse{URL: url})
+}
+
+func StopTensorBoard(w http.ResponseWriter, r *http.Request) {
+	config.Telemetry.ReportEvent("endpoint.tensorboard.stop")
+
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+	modelName, err := getRequiredQueryParam("modelName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	if !workloads.StopTensorBoard(appName, modelName) {
+		RespondError(w, workloads.ErrorTensorBoardNotRunning(modelName))
+		return
+	}
+
+	Respond(w, schema.StopTensorBoardResponse{Message: ResTensorBoardStopped})
+}
@@ -78,6 +78,12 @@ func main() {
 	router.HandleFunc("/delete", endpoints.Delete).Methods("POST")
 	router.HandleFunc("/resources", endpoints.GetResources).Methods("GET")
 	router.HandleFunc("/aggregate/{id}", endpoints.GetAggregate).Methods("GET")
+	router.HandleFunc("/raw_column/{id}/quality_report", endpoints.GetRawColumnQualityReport).Methods("GET")
+	router.HandleFunc("/model/{id}/metrics", endpoints.GetModelMetrics).Methods("GET")
+	router.HandleFunc("/model/{id}/manifest", endpoints.GetModelManifest).Methods("GET")
+	router.HandleFunc("/model/runs", endpoints.GetModelRuns).Methods("GET")
+	router.HandleFunc("/tensorboard/start", endpoints.StartTensorBoard).Methods("POST")
+	router.HandleFunc("/tensorboard/stop", endpoints.StopTensorBoard).Methods("POST")
 	router.HandleFunc("/logs/read", endpoints.ReadLogs)
 
 	log.Print("Running on port " + operatorPortStr)
@@ -173,6 +179,21 @@ func runCron() {
 		config.Telemetry.ReportError(err)
 		errors.PrintError(err)
 	}
+
+	if err := workloads.TerminateTimedOutWorkloads(); err != nil {
+		config.Telemetry.ReportError(err)
+		errors.PrintError(err)
+	}
+
+	if err := workloads.RecordImageDigests(); err != nil {
+		config.Telemetry.ReportError(err)
+		errors.PrintError(err)
+	}
+
+	if err := workloads.DeleteExpiredTensorBoards(); err != nil {
+		config.Telemetry.ReportError(err)
+		errors.PrintError(err)
+	}
 }
 
 func deleteWorkflowDelayed(wfName string) {
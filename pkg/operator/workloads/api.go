@@ -60,13 +60,20 @@ func apiSpec(
 		tfServingResourceList[corev1.ResourceMemory] = *q2
 	}
 
-	servingImage := config.Cortex.TFServeImage
+	framework := config.Cortex.Frameworks[ctx.Models[ctx.APIs[apiName].ModelName].Framework]
+
+	servingImage := framework.ServeImage
 	if apiCompute.GPU > 0 {
-		servingImage = config.Cortex.TFServeImageGPU
+		servingImage = framework.ServeImageGPU
 		tfServingResourceList["nvidia.com/gpu"] = *k8sresource.NewQuantity(apiCompute.GPU, k8sresource.DecimalSI)
 		tfServingLimitsList["nvidia.com/gpu"] = *k8sresource.NewQuantity(apiCompute.GPU, k8sresource.DecimalSI)
 	}
 
+	apiImage := config.Cortex.TFAPIImage
+	if ctx.APIs[apiName].Image != nil {
+		apiImage = *ctx.APIs[apiName].Image
+	}
+
 	return k8s.Deployment(&k8s.DeploymentSpec{
 		Name:     internalAPIName(apiName, ctx.App.Name),
 		Replicas: ctx.APIs[apiName].Compute.Replicas,
@@ -95,7 +102,7 @@ func apiSpec(
 				Containers: []corev1.Container{
 					{
 						Name:            apiContainerName,
-						Image:           config.Cortex.TFAPIImage,
+						Image:           apiImage,
 						ImagePullPolicy: "Always",
 						Args: []string{
 							"--workload-id=" + workloadID,
@@ -131,11 +138,11 @@ func apiSpec(
 						Name:            tfServingContainerName,
 						Image:           servingImage,
 						ImagePullPolicy: "Always",
-						Args: []string{
-							"--port=" + tfServingPortStr,
-							"--model_base_path=" + path.Join(consts.EmptyDirMountPath, "model"),
-						},
-						Env:          k8s.AWSCredentials(),
+						Args:            framework.ServeArgs,
+						Env: append(k8s.AWSCredentials(),
+							corev1.EnvVar{Name: "PORT", Value: tfServingPortStr},
+							corev1.EnvVar{Name: "MODEL_DIR", Value: path.Join(consts.EmptyDirMountPath, "model")},
+						),
 						VolumeMounts: k8s.DefaultVolumeMounts(),
 						ReadinessProbe: &corev1.Probe{
 							InitialDelaySeconds: 5,
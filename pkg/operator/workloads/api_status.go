@@ -195,7 +195,7 @@ func updateAPIStatusCodeByParents(apiStatus *resource.APIStatus, dataStatuses ma
 	parentSkipped := false
 	for dependency := range allDependencies {
 		switch dataStatuses[dependency].Code {
-		case resource.StatusKilled, resource.StatusKilledOOM:
+		case resource.StatusKilled, resource.StatusKilledOOM, resource.StatusTimedOut:
 			apiStatus.Code = resource.StatusParentKilled
 			return
 		case resource.StatusFailed:
@@ -21,9 +21,16 @@ const (
 	workloadTypeData           = "data-job"
 	workloadTypeTrain          = "training-job"
 	workloadTypePythonPackager = "python-packager"
+	WorkloadTypeTensorBoard    = "tensorboard"
 
-	defaultPortInt32, defaultPortStr     = int32(8888), "8888"
-	tfServingPortInt32, tfServingPortStr = int32(9000), "9000"
+	defaultPortInt32, defaultPortStr         = int32(8888), "8888"
+	tfServingPortInt32, tfServingPortStr     = int32(9000), "9000"
+	tensorBoardPortInt32, tensorBoardPortStr = int32(6006), "6006"
 
-	userFacingCheckInterval = 1 // seconds
+	maxDurationAnnotation  = "maxDurationSecs"
+	lastAccessedAnnotation = "lastAccessed"
+
+	userFacingCheckInterval        = 1    // seconds
+	tensorBoardIdleTimeout         = 3600 // seconds
+	tensorBoardAccessCheckInterval = 30   // seconds
 )
@@ -18,6 +18,7 @@ package workloads
 
 import (
 	"path/filepath"
+	"sort"
 	"strings"
 
 	sparkop "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
@@ -44,6 +45,7 @@ func dataJobSpec(
 	trainingDatasets strset.Set,
 	workloadID string,
 	sparkCompute *userconfig.SparkCompute,
+	image string,
 ) *sparkop.SparkApplication {
 
 	args := []string{
@@ -55,12 +57,12 @@ func dataJobSpec(
 	if shouldIngest {
 		args = append(args, "--ingest")
 	}
-	spec := sparkSpec(workloadID, ctx, workloadTypeData, sparkCompute, args...)
+	spec := sparkSpec(workloadID, ctx, workloadTypeData, sparkCompute, image, args...)
 	argo.EnableGC(spec)
 	return spec
 }
 
-func sparkSpec(workloadID string, ctx *context.Context, workloadType string, sparkCompute *userconfig.SparkCompute, args ...string) *sparkop.SparkApplication {
+func sparkSpec(workloadID string, ctx *context.Context, workloadType string, sparkCompute *userconfig.SparkCompute, image string, args ...string) *sparkop.SparkApplication {
 	var driverMemOverhead *string
 	if sparkCompute.DriverMemOverhead != nil {
 		driverMemOverhead = pointer.String(s.Int64(sparkCompute.DriverMemOverhead.ToKi()) + "k")
@@ -74,14 +76,21 @@ func sparkSpec(workloadID string, ctx *context.Context, workloadType string, spa
 		memOverheadFactor = pointer.String(s.Float64(*sparkCompute.MemOverheadFactor))
 	}
 
+	// SparkApplications don't support deadlines, so the operator terminates them (see TerminateTimedOutWorkloads)
+	annotations := map[string]string{}
+	if maxDurationSecs := userconfig.DurationSecs(sparkCompute.MaxDuration); maxDurationSecs != nil {
+		annotations[maxDurationAnnotation] = s.Int64(*maxDurationSecs)
+	}
+
 	return &sparkop.SparkApplication{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "sparkoperator.k8s.io/v1alpha1",
 			Kind:       "SparkApplication",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      workloadID,
-			Namespace: config.Cortex.Namespace,
+			Name:        workloadID,
+			Namespace:   config.Cortex.Namespace,
+			Annotations: annotations,
 			Labels: map[string]string{
 				"workloadID":   workloadID,
 				"workloadType": workloadType,
@@ -92,7 +101,7 @@ func sparkSpec(workloadID string, ctx *context.Context, workloadType string, spa
 			Type:                 sparkop.PythonApplicationType,
 			PythonVersion:        pointer.String("3"),
 			Mode:                 sparkop.ClusterMode,
-			Image:                &config.Cortex.SparkImage,
+			Image:                &image,
 			ImagePullPolicy:      pointer.String("Always"),
 			MainApplicationFile:  pointer.String("local:///src/spark_job/spark_job.py"),
 			RestartPolicy:        sparkop.RestartPolicy{Type: sparkop.Never},
@@ -118,16 +127,7 @@ func sparkSpec(workloadID string, ctx *context.Context, workloadType string, spa
 						"appName":      ctx.App.Name,
 						"userFacing":   "true",
 					},
-					EnvSecretKeyRefs: map[string]sparkop.NameKey{
-						"AWS_ACCESS_KEY_ID": {
-							Name: "aws-credentials",
-							Key:  "AWS_ACCESS_KEY_ID",
-						},
-						"AWS_SECRET_ACCESS_KEY": {
-							Name: "aws-credentials",
-							Key:  "AWS_SECRET_ACCESS_KEY",
-						},
-					},
+					EnvSecretKeyRefs: sparkEnvSecretKeyRefs(ctx),
 					EnvVars: map[string]string{
 						"CORTEX_SPARK_VERBOSITY": ctx.Environment.LogLevel.Spark,
 						"CORTEX_CONTEXT_S3_PATH": config.AWS.S3Path(ctx.Key),
@@ -148,16 +148,7 @@ func sparkSpec(workloadID string, ctx *context.Context, workloadType string, spa
 						"workloadType": workloadType,
 						"appName":      ctx.App.Name,
 					},
-					EnvSecretKeyRefs: map[string]sparkop.NameKey{
-						"AWS_ACCESS_KEY_ID": {
-							Name: "aws-credentials",
-							Key:  "AWS_ACCESS_KEY_ID",
-						},
-						"AWS_SECRET_ACCESS_KEY": {
-							Name: "aws-credentials",
-							Key:  "AWS_SECRET_ACCESS_KEY",
-						},
-					},
+					EnvSecretKeyRefs: sparkEnvSecretKeyRefs(ctx),
 					EnvVars: map[string]string{
 						"CORTEX_SPARK_VERBOSITY": ctx.Environment.LogLevel.Spark,
 						"CORTEX_CONTEXT_S3_PATH": config.AWS.S3Path(ctx.Key),
@@ -171,112 +162,211 @@ func sparkSpec(workloadID string, ctx *context.Context, workloadType string, spa
 	}
 }
 
-func dataWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
-	workloadID := generateWorkloadID()
+func sparkEnvSecretKeyRefs(ctx *context.Context) map[string]sparkop.NameKey {
+	secretKeyRefs := map[string]sparkop.NameKey{
+		"AWS_ACCESS_KEY_ID": {
+			Name: "aws-credentials",
+			Key:  "AWS_ACCESS_KEY_ID",
+		},
+		"AWS_SECRET_ACCESS_KEY": {
+			Name: "aws-credentials",
+			Key:  "AWS_SECRET_ACCESS_KEY",
+		},
+	}
 
+	// JDBC credentials are read from a user-provided secret with "username" and "password" keys
+	addJDBCSecretKeyRefs := func(data userconfig.Data, envSuffix string) {
+		if jdbcData, ok := data.(*userconfig.JDBCData); ok && jdbcData.CredentialsSecret != nil {
+			secretKeyRefs["CORTEX_JDBC_USERNAME"+envSuffix] = sparkop.NameKey{
+				Name: *jdbcData.CredentialsSecret,
+				Key:  "username",
+			}
+			secretKeyRefs["CORTEX_JDBC_PASSWORD"+envSuffix] = sparkop.NameKey{
+				Name: *jdbcData.CredentialsSecret,
+				Key:  "password",
+			}
+		}
+	}
+
+	// Joined sources are identified by their index (e.g. CORTEX_JDBC_USERNAME_0)
+	if joinData, ok := ctx.Environment.Data.(*userconfig.JoinData); ok {
+		for i, source := range joinData.Sources {
+			addJDBCSecretKeyRefs(source.Data, "_"+s.Int(i))
+		}
+	} else {
+		addJDBCSecretKeyRefs(ctx.Environment.Data, "")
+	}
+
+	return secretKeyRefs
+}
+
+// dataJob is a Spark job which computes the resources which use one image
+type dataJob struct {
+	image                string
+	shouldIngest         bool
+	rawColumnIDs         strset.Set
+	aggregateIDs         strset.Set
+	transformedColumnIDs strset.Set
+	trainingDatasetIDs   strset.Set
+	computes             []*userconfig.SparkCompute
+}
+
+func dataWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 	rawFileExists, err := config.AWS.IsS3File(filepath.Join(ctx.RawDataset.Key, "_SUCCESS"))
 	if err != nil {
 		return nil, errors.Wrap(err, ctx.App.Name, "raw dataset")
 	}
 
-	var allComputes []*userconfig.SparkCompute
-
 	shouldIngest := !rawFileExists
 	if shouldIngest {
-		externalDataPath := ctx.Environment.Data.GetExternalPath()
-		externalDataExists, err := config.AWS.IsS3aPrefixExternal(externalDataPath)
-		if err != nil || !externalDataExists {
-			return nil, errors.Wrap(ErrorUserDataUnavailable(externalDataPath), ctx.App.Name, userconfig.Identify(ctx.Environment), userconfig.DataKey, userconfig.PathKey)
+		// JDBC data has no external path, so its availability is only known once the job connects
+		externalDataPaths := []string{ctx.Environment.Data.GetExternalPath()}
+		if joinData, ok := ctx.Environment.Data.(*userconfig.JoinData); ok {
+			externalDataPaths = joinData.GetExternalPaths()
+		}
+		for _, externalDataPath := range externalDataPaths {
+			if externalDataPath == "" {
+				continue
+			}
+			externalDataExists, err := config.AWS.IsS3aPrefixExternal(externalDataPath)
+			if err != nil || !externalDataExists {
+				return nil, errors.Wrap(ErrorUserDataUnavailable(externalDataPath), ctx.App.Name, userconfig.Identify(ctx.Environment), userconfig.DataKey, userconfig.PathKey)
+			}
+		}
+	}
+
+	jobs, err := groupDataJobs(ctx, shouldIngest, func(res context.ComputedResource) (bool, error) {
+		return checkResourceCached(res, ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var workloadSpecs []*WorkloadSpec
+	for _, job := range jobs {
+		resourceIDSet := strset.Union(job.rawColumnIDs, job.aggregateIDs, job.transformedColumnIDs, job.trainingDatasetIDs)
+		workloadID := generateWorkloadID()
+		sparkCompute := userconfig.MaxSparkCompute(job.computes...)
+		spec := dataJobSpec(ctx, job.shouldIngest, job.rawColumnIDs, job.aggregateIDs, job.transformedColumnIDs, job.trainingDatasetIDs, workloadID, sparkCompute, job.image)
+
+		workloadSpecs = append(workloadSpecs, &WorkloadSpec{
+			WorkloadID:       workloadID,
+			ResourceIDs:      resourceIDSet,
+			Spec:             spec,
+			K8sAction:        "create",
+			SuccessCondition: spark.SuccessCondition,
+			FailureCondition: spark.FailureCondition,
+			WorkloadType:     workloadTypeData,
+		})
+	}
+	return workloadSpecs, nil
+}
+
+// groupDataJobs splits the uncached data resources into one Spark job per image (sorted by image), skipping jobs with nothing to do.
+// Raw columns and ingestion always use the default Spark image.
+func groupDataJobs(ctx *context.Context, shouldIngest bool, isCached func(context.ComputedResource) (bool, error)) ([]*dataJob, error) {
+	jobs := make(map[string]*dataJob)
+	getJob := func(image *string) *dataJob {
+		imageStr := config.Cortex.SparkImage
+		if image != nil {
+			imageStr = *image
 		}
+		if _, ok := jobs[imageStr]; !ok {
+			jobs[imageStr] = &dataJob{
+				image:                imageStr,
+				rawColumnIDs:         strset.New(),
+				aggregateIDs:         strset.New(),
+				transformedColumnIDs: strset.New(),
+				trainingDatasetIDs:   strset.New(),
+			}
+		}
+		return jobs[imageStr]
+	}
+
+	if shouldIngest {
+		job := getJob(nil)
+		job.shouldIngest = true
 		for _, rawColumn := range ctx.RawColumns {
-			allComputes = append(allComputes, rawColumn.GetCompute())
+			job.computes = append(job.computes, rawColumn.GetCompute())
 		}
 	}
 
-	rawColumnIDs := strset.New()
-	var rawColumns []string
-	for rawColumnName, rawColumn := range ctx.RawColumns {
-		isCached, err := checkResourceCached(rawColumn, ctx)
+	for _, rawColumn := range ctx.RawColumns {
+		cached, err := isCached(rawColumn)
 		if err != nil {
 			return nil, err
 		}
-		if isCached {
+		if cached {
 			continue
 		}
-		rawColumns = append(rawColumns, rawColumnName)
-		rawColumnIDs.Add(rawColumn.GetID())
-		allComputes = append(allComputes, rawColumn.GetCompute())
+		job := getJob(nil)
+		job.rawColumnIDs.Add(rawColumn.GetID())
+		job.computes = append(job.computes, rawColumn.GetCompute())
 	}
 
-	aggregateIDs := strset.New()
-	var aggregates []string
-	for aggregateName, aggregate := range ctx.Aggregates {
-		isCached, err := checkResourceCached(aggregate, ctx)
+	for _, aggregate := range ctx.Aggregates {
+		cached, err := isCached(aggregate)
 		if err != nil {
 			return nil, err
 		}
-		if isCached {
+		if cached {
 			continue
 		}
-		aggregates = append(aggregates, aggregateName)
-		aggregateIDs.Add(aggregate.GetID())
-		allComputes = append(allComputes, aggregate.Compute)
+		job := getJob(aggregate.Image)
+		job.aggregateIDs.Add(aggregate.GetID())
+		job.computes = append(job.computes, aggregate.Compute)
 	}
 
-	transformedColumnIDs := strset.New()
-	var transformedColumns []string
-	for transformedColumnName, transformedColumn := range ctx.TransformedColumns {
-		isCached, err := checkResourceCached(transformedColumn, ctx)
+	for _, transformedColumn := range ctx.TransformedColumns {
+		cached, err := isCached(transformedColumn)
 		if err != nil {
 			return nil, err
 		}
-		if isCached {
+		if cached {
 			continue
 		}
-		transformedColumns = append(transformedColumns, transformedColumnName)
-		transformedColumnIDs.Add(transformedColumn.GetID())
-		allComputes = append(allComputes, transformedColumn.Compute)
+		job := getJob(transformedColumn.Image)
+		job.transformedColumnIDs.Add(transformedColumn.GetID())
+		job.computes = append(job.computes, transformedColumn.Compute)
 	}
 
-	trainingDatasetIDs := strset.New()
-	var trainingDatasets []string
-	for modelName, model := range ctx.Models {
+	for _, model := range ctx.Models {
 		dataset := model.Dataset
-		isCached, err := checkResourceCached(dataset, ctx)
+		if dataset == nil {
+			continue
+		}
+		cached, err := isCached(dataset)
 		if err != nil {
 			return nil, err
 		}
-		if isCached {
+		if cached {
 			continue
 		}
-		trainingDatasets = append(trainingDatasets, modelName)
-		trainingDatasetIDs.Add(dataset.GetID())
+		job := getJob(dataset.Image)
+		job.trainingDatasetIDs.Add(dataset.GetID())
 		dependencyIDs := ctx.AllComputedResourceDependencies(dataset.GetID())
 		for _, transformedColumn := range ctx.TransformedColumns {
 			if _, ok := dependencyIDs[transformedColumn.ID]; ok {
-				allComputes = append(allComputes, transformedColumn.Compute)
+				job.computes = append(job.computes, transformedColumn.Compute)
 			}
 		}
-		allComputes = append(allComputes, model.DatasetCompute)
+		job.computes = append(job.computes, model.DatasetCompute)
 	}
 
-	resourceIDSet := strset.Union(rawColumnIDs, aggregateIDs, transformedColumnIDs, trainingDatasetIDs)
-
-	if !shouldIngest && len(resourceIDSet) == 0 {
-		return nil, nil
+	images := make([]string, 0, len(jobs))
+	for image := range jobs {
+		images = append(images, image)
 	}
+	sort.Strings(images)
 
-	sparkCompute := userconfig.MaxSparkCompute(allComputes...)
-	spec := dataJobSpec(ctx, shouldIngest, rawColumnIDs, aggregateIDs, transformedColumnIDs, trainingDatasetIDs, workloadID, sparkCompute)
-
-	workloadSpec := &WorkloadSpec{
-		WorkloadID:       workloadID,
-		ResourceIDs:      resourceIDSet,
-		Spec:             spec,
-		K8sAction:        "create",
-		SuccessCondition: spark.SuccessCondition,
-		FailureCondition: spark.FailureCondition,
-		WorkloadType:     workloadTypeData,
+	var sortedJobs []*dataJob
+	for _, image := range images {
+		job := jobs[image]
+		resourceIDSet := strset.Union(job.rawColumnIDs, job.aggregateIDs, job.transformedColumnIDs, job.trainingDatasetIDs)
+		if !job.shouldIngest && len(resourceIDSet) == 0 {
+			continue
+		}
+		sortedJobs = append(sortedJobs, job)
 	}
-	return []*WorkloadSpec{workloadSpec}, nil
+	return sortedJobs, nil
 }
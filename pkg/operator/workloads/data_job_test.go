@@ -0,0 +1,209 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/cortexlabs/cortex/pkg/operator/api/context"
+	"github.com/cortexlabs/cortex/pkg/operator/api/userconfig"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+)
+
+func newTestComputedResourceFields(id string) *context.ComputedResourceFields {
+	return &context.ComputedResourceFields{ResourceFields: &context.ResourceFields{ID: id}}
+}
+
+func newTestDataJobContext(aggregateImage *string, transformedColumnImage *string, datasetImage *string) *context.Context {
+	return &context.Context{
+		RawColumns: context.RawColumns{
+			"age": &context.RawIntColumn{
+				RawIntColumn:           &userconfig.RawIntColumn{ResourceConfigFields: userconfig.ResourceConfigFields{Name: "age"}},
+				ComputedResourceFields: newTestComputedResourceFields("raw_age"),
+			},
+		},
+		Aggregates: context.Aggregates{
+			"age_mean": &context.Aggregate{
+				Aggregate: &userconfig.Aggregate{
+					ResourceConfigFields: userconfig.ResourceConfigFields{Name: "age_mean"},
+					Inputs:               &userconfig.Inputs{Columns: map[string]interface{}{"col": "age"}},
+					Image:                aggregateImage,
+				},
+				ComputedResourceFields: newTestComputedResourceFields("agg_age_mean"),
+			},
+		},
+		TransformedColumns: context.TransformedColumns{
+			"age_normalized": &context.TransformedColumn{
+				TransformedColumn: &userconfig.TransformedColumn{
+					ResourceConfigFields: userconfig.ResourceConfigFields{Name: "age_normalized"},
+					Inputs: &userconfig.Inputs{
+						Columns: map[string]interface{}{"num": "age"},
+						Args:    map[string]interface{}{"mean": "age_mean"},
+					},
+					Image: transformedColumnImage,
+				},
+				ComputedResourceFields: newTestComputedResourceFields("tc_age_normalized"),
+			},
+		},
+		Models: context.Models{
+			"dnn": &context.Model{
+				Model: &userconfig.Model{
+					ResourceConfigFields: userconfig.ResourceConfigFields{Name: "dnn"},
+					FeatureColumns:       []string{"age_normalized"},
+					TargetColumn:         "age",
+				},
+				ComputedResourceFields: newTestComputedResourceFields("model_dnn"),
+				Dataset: &context.TrainingDataset{
+					ResourceConfigFields:   userconfig.ResourceConfigFields{Name: "dnn/training_dataset"},
+					ComputedResourceFields: newTestComputedResourceFields("dataset_dnn"),
+					ModelName:              "dnn",
+					Image:                  datasetImage,
+				},
+			},
+		},
+	}
+}
+
+func TestGroupDataJobs(t *testing.T) {
+	config.Cortex = &config.CortexConfig{SparkImage: "cortexlabs/spark"}
+
+	type expectedJob struct {
+		image                string
+		shouldIngest         bool
+		rawColumnIDs         []string
+		aggregateIDs         []string
+		transformedColumnIDs []string
+		trainingDatasetIDs   []string
+	}
+
+	customImage := "registry.io/team/spark"
+
+	testCases := []struct {
+		name         string
+		ctx          *context.Context
+		shouldIngest bool
+		cachedIDs    []string
+		expected     []expectedJob
+	}{
+		{
+			name:         "default images",
+			ctx:          newTestDataJobContext(nil, nil, nil),
+			shouldIngest: true,
+			expected: []expectedJob{
+				{
+					image:                "cortexlabs/spark",
+					shouldIngest:         true,
+					rawColumnIDs:         []string{"raw_age"},
+					aggregateIDs:         []string{"agg_age_mean"},
+					transformedColumnIDs: []string{"tc_age_normalized"},
+					trainingDatasetIDs:   []string{"dataset_dnn"},
+				},
+			},
+		},
+		{
+			name:         "custom transformed column and dataset image",
+			ctx:          newTestDataJobContext(nil, &customImage, &customImage),
+			shouldIngest: true,
+			expected: []expectedJob{
+				{
+					image:        "cortexlabs/spark",
+					shouldIngest: true,
+					rawColumnIDs: []string{"raw_age"},
+					aggregateIDs: []string{"agg_age_mean"},
+				},
+				{
+					image:                customImage,
+					transformedColumnIDs: []string{"tc_age_normalized"},
+					trainingDatasetIDs:   []string{"dataset_dnn"},
+				},
+			},
+		},
+		{
+			name:         "custom aggregate image",
+			ctx:          newTestDataJobContext(&customImage, nil, nil),
+			shouldIngest: false,
+			cachedIDs:    []string{"raw_age"},
+			expected: []expectedJob{
+				{
+					image:                "cortexlabs/spark",
+					transformedColumnIDs: []string{"tc_age_normalized"},
+					trainingDatasetIDs:   []string{"dataset_dnn"},
+				},
+				{
+					image:        customImage,
+					aggregateIDs: []string{"agg_age_mean"},
+				},
+			},
+		},
+		{
+			name:         "custom image job is skipped when its resources are cached",
+			ctx:          newTestDataJobContext(nil, &customImage, &customImage),
+			shouldIngest: false,
+			cachedIDs:    []string{"tc_age_normalized", "dataset_dnn"},
+			expected: []expectedJob{
+				{
+					image:        "cortexlabs/spark",
+					rawColumnIDs: []string{"raw_age"},
+					aggregateIDs: []string{"agg_age_mean"},
+				},
+			},
+		},
+		{
+			name:         "all cached without ingestion",
+			ctx:          newTestDataJobContext(nil, &customImage, nil),
+			shouldIngest: false,
+			cachedIDs:    []string{"raw_age", "agg_age_mean", "tc_age_normalized", "dataset_dnn"},
+			expected:     nil,
+		},
+		{
+			name:         "all cached with ingestion",
+			ctx:          newTestDataJobContext(nil, &customImage, nil),
+			shouldIngest: true,
+			cachedIDs:    []string{"raw_age", "agg_age_mean", "tc_age_normalized", "dataset_dnn"},
+			expected: []expectedJob{
+				{
+					image:        "cortexlabs/spark",
+					shouldIngest: true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		cachedIDs := strset.New(tc.cachedIDs...)
+		isCached := func(res context.ComputedResource) (bool, error) {
+			return cachedIDs.Has(res.GetID()), nil
+		}
+
+		jobs, err := groupDataJobs(tc.ctx, tc.shouldIngest, isCached)
+		require.NoError(t, err, tc.name)
+		require.Len(t, jobs, len(tc.expected), tc.name)
+
+		for i, expected := range tc.expected {
+			job := jobs[i]
+			require.Equal(t, expected.image, job.image, tc.name)
+			require.Equal(t, expected.shouldIngest, job.shouldIngest, tc.name)
+			require.ElementsMatch(t, expected.rawColumnIDs, job.rawColumnIDs.Slice(), tc.name)
+			require.ElementsMatch(t, expected.aggregateIDs, job.aggregateIDs.Slice(), tc.name)
+			require.ElementsMatch(t, expected.transformedColumnIDs, job.transformedColumnIDs.Slice(), tc.name)
+			require.ElementsMatch(t, expected.trainingDatasetIDs, job.trainingDatasetIDs.Slice(), tc.name)
+		}
+	}
+}
@@ -79,6 +79,8 @@ func dataStatusCode(dataSavedStatus *resource.DataSavedStatus) resource.StatusCo
 		return resource.StatusKilled
 	case resource.ExitCodeDataOOM:
 		return resource.StatusKilledOOM
+	case resource.ExitCodeDataTimedOut:
+		return resource.StatusTimedOut
 	}
 
 	return resource.StatusUnknown
@@ -93,7 +95,7 @@ func updateDataStatusCodeByParents(dataStatus *resource.DataStatus, dataStatuses
 	parentSkipped := false
 	for dependency := range allDependencies {
 		switch dataStatuses[dependency].Code {
-		case resource.StatusKilled, resource.StatusKilledOOM:
+		case resource.StatusKilled, resource.StatusKilledOOM, resource.StatusTimedOut:
 			dataStatus.Code = resource.StatusParentKilled
 			return
 		case resource.StatusFailed:
@@ -139,7 +141,9 @@ func sparkGeneratedResources(ctx *context.Context) map[string]context.Resource {
 		resources[transformedColumn.ID] = transformedColumn
 	}
 	for _, model := range ctx.Models {
-		resources[model.Dataset.ID] = model.Dataset
+		if model.Dataset != nil {
+			resources[model.Dataset.ID] = model.Dataset
+		}
 	}
 	return resources
 }
@@ -31,6 +31,8 @@ const (
 	ErrCortexInstallationBroken
 	ErrLoadBalancerInitializing
 	ErrNotFound
+	ErrTensorBoardExternalModel
+	ErrTensorBoardNotRunning
 )
 
 var errorKinds = []string{
@@ -42,9 +44,11 @@ var errorKinds = []string{
 	"err_cortex_installation_broken",
 	"err_load_balancer_initializing",
 	"err_not_found",
+	"err_tensorboard_external_model",
+	"err_tensorboard_not_running",
 }
 
-var _ = [1]int{}[int(ErrNotFound)-(len(errorKinds)-1)] // Ensure list length matches
+var _ = [1]int{}[int(ErrTensorBoardNotRunning)-(len(errorKinds)-1)] // Ensure list length matches
 
 func (t ErrorKind) String() string {
 	return errorKinds[t]
@@ -137,3 +141,17 @@ func ErrorNotFound() error {
 		message: "not found",
 	}
 }
+
+func ErrorTensorBoardExternalModel(modelName string) error {
+	return Error{
+		Kind:    ErrTensorBoardExternalModel,
+		message: fmt.Sprintf("model %s is an external model, so it has no training summaries to view in TensorBoard", modelName),
+	}
+}
+
+func ErrorTensorBoardNotRunning(modelName string) error {
+	return Error{
+		Kind:    ErrTensorBoardNotRunning,
+		message: fmt.Sprintf("TensorBoard is not running for model %s", modelName),
+	}
+}
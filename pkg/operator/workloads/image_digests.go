@@ -0,0 +1,205 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"path/filepath"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/aws"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/cortexlabs/cortex/pkg/operator/api/context"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+)
+
+type workloadIDSetCache struct {
+	m map[string]strset.Set // appName -> workload IDs
+	sync.RWMutex
+}
+
+// workload IDs whose image digests have been saved
+var imageDigestsCache = &workloadIDSetCache{m: make(map[string]strset.Set)}
+
+// training workload IDs whose image digests have been saved in their model's manifest
+var manifestImageDigestsCache = &workloadIDSetCache{m: make(map[string]strset.Set)}
+
+func imageDigestsKey(workloadID string, appName string) string {
+	return filepath.Join(
+		consts.AppsDir,
+		appName,
+		consts.ImageDigestsDir,
+		workloadID+".json",
+	)
+}
+
+// RecordImageDigests saves the digests of the images run by training and data workloads,
+// and adds them to the manifest of each model once its training workload has succeeded
+func RecordImageDigests() error {
+	currentWorkloadIDs := make(map[string]strset.Set)
+	var succeededTrainPods []corev1.Pod
+	for _, workloadType := range []string{workloadTypeTrain, workloadTypeData} {
+		pods, err := config.Kubernetes.ListPodsByLabel("workloadType", workloadType)
+		if err != nil {
+			return errors.Wrap(err, "image digests")
+		}
+
+		for _, pod := range pods {
+			workloadID := pod.Labels["workloadID"]
+			appName := pod.Labels["appName"]
+			if workloadID == "" || appName == "" {
+				continue
+			}
+			if _, ok := currentWorkloadIDs[appName]; !ok {
+				currentWorkloadIDs[appName] = strset.New()
+			}
+			if workloadType == workloadTypeTrain && pod.Status.Phase == corev1.PodSucceeded {
+				succeededTrainPods = append(succeededTrainPods, pod)
+			}
+			if currentWorkloadIDs[appName].Has(workloadID) {
+				continue
+			}
+
+			digests := k8s.GetPodImageDigests(&pod)
+			if len(digests) == 0 {
+				continue // the pod's containers haven't started yet
+			}
+			currentWorkloadIDs[appName].Add(workloadID)
+			if imageDigestsCache.has(workloadID, appName) {
+				continue
+			}
+
+			if err := config.AWS.UploadJSONToS3(digests, imageDigestsKey(workloadID, appName)); err != nil {
+				return errors.Wrap(err, "upload image digests", appName, workloadID)
+			}
+			imageDigestsCache.add(workloadID, appName)
+		}
+	}
+
+	// The manifest is saved by the training workload before it succeeds, and its upstream workloads' digests have been saved by then
+	for _, pod := range succeededTrainPods {
+		if err := saveManifestImageDigests(pod.Labels["workloadID"], pod.Labels["appName"]); err != nil {
+			return err
+		}
+	}
+
+	imageDigestsCache.keep(currentWorkloadIDs)
+	manifestImageDigestsCache.keep(currentWorkloadIDs)
+	return nil
+}
+
+// saveManifestImageDigests adds the image digests to the manifest of the model trained by the workload (fold workloads don't save a manifest)
+func saveManifestImageDigests(workloadID string, appName string) error {
+	if manifestImageDigestsCache.has(workloadID, appName) {
+		return nil
+	}
+	ctx := CurrentContext(appName)
+	if ctx == nil {
+		return nil
+	}
+
+	for _, model := range ctx.Models {
+		if model.IsExternal() {
+			continue
+		}
+		latestWorkloadID, err := GetLatestWorkloadID(model.ID, appName)
+		if err != nil {
+			return err
+		}
+		if latestWorkloadID != workloadID {
+			continue
+		}
+
+		var manifest context.ModelManifest
+		err = config.AWS.ReadJSONFromS3(&manifest, model.ManifestKey)
+		if aws.IsNoSuchKeyErr(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "download model manifest", appName, model.Name)
+		}
+		if manifest.ImageDigests != nil {
+			continue
+		}
+
+		manifest.ImageDigests, err = GetImageDigests(manifest.WorkloadIDs, appName)
+		if err != nil {
+			return err
+		}
+		if err := config.AWS.UploadJSONToS3(manifest, model.ManifestKey); err != nil {
+			return errors.Wrap(err, "upload model manifest", appName, model.Name)
+		}
+	}
+
+	manifestImageDigestsCache.add(workloadID, appName)
+	return nil
+}
+
+// GetImageDigests returns the digests of the images run by the workloads (workloads whose digests weren't recorded are skipped)
+func GetImageDigests(workloadIDs []string, appName string) (map[string]string, error) {
+	digests := make(map[string]string)
+	for _, workloadID := range workloadIDs {
+		var workloadDigests map[string]string
+		err := config.AWS.ReadJSONFromS3(&workloadDigests, imageDigestsKey(workloadID, appName))
+		if aws.IsNoSuchKeyErr(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "download image digests", appName, workloadID)
+		}
+		for image, digest := range workloadDigests {
+			digests[image] = digest
+		}
+	}
+	return digests, nil
+}
+
+func (cache *workloadIDSetCache) has(workloadID string, appName string) bool {
+	cache.RLock()
+	defer cache.RUnlock()
+	return cache.m[appName] != nil && cache.m[appName].Has(workloadID)
+}
+
+func (cache *workloadIDSetCache) add(workloadID string, appName string) {
+	cache.Lock()
+	defer cache.Unlock()
+	if _, ok := cache.m[appName]; !ok {
+		cache.m[appName] = strset.New()
+	}
+	cache.m[appName].Add(workloadID)
+}
+
+// keep removes the workload IDs which aren't in currentWorkloadIDs (appName -> workload IDs to keep)
+func (cache *workloadIDSetCache) keep(currentWorkloadIDs map[string]strset.Set) {
+	cache.Lock()
+	defer cache.Unlock()
+	for appName := range cache.m {
+		if _, ok := currentWorkloadIDs[appName]; !ok {
+			delete(cache.m, appName)
+		} else {
+			for workloadID := range cache.m[appName] {
+				if !currentWorkloadIDs[appName].Has(workloadID) {
+					cache.m[appName].Remove(workloadID)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,347 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	cron "gopkg.in/robfig/cron.v2"
+
+	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/argo"
+	"github.com/cortexlabs/cortex/pkg/lib/aws"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/zip"
+	"github.com/cortexlabs/cortex/pkg/operator/api/context"
+	"github.com/cortexlabs/cortex/pkg/operator/api/resource"
+	"github.com/cortexlabs/cortex/pkg/operator/api/userconfig"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+)
+
+var scheduleCron = cron.New()
+
+// appName -> registered schedules
+var appSchedules = struct {
+	m map[string][]*appSchedule
+	sync.Mutex
+}{m: make(map[string][]*appSchedule)}
+
+// appName -> true if a scheduled run is currently being started
+var scheduledRunsInProgress = struct {
+	m map[string]bool
+	sync.Mutex
+}{m: make(map[string]bool)}
+
+type appSchedule struct {
+	entryID cron.EntryID
+	status  *resource.ScheduleStatus
+}
+
+// The config and environment of the latest deployment, used to rebuild the context on scheduled runs
+type scheduledDeployment struct {
+	Environment string `json:"environment"`
+	ConfigZip   []byte `json:"config_zip"`
+}
+
+func scheduledDeploymentKey(appName string) string {
+	return filepath.Join(
+		consts.AppsDir,
+		appName,
+		consts.SchedulesDir,
+		"deployment.msgpack",
+	)
+}
+
+func scheduleLastRunsKey(appName string) string {
+	return filepath.Join(
+		consts.AppsDir,
+		appName,
+		consts.SchedulesDir,
+		"last_runs.json",
+	)
+}
+
+func scheduleStatusKey(resourceType resource.Type, resourceName string) string {
+	return resourceType.String() + "/" + resourceName
+}
+
+func HasSchedule(ctx *context.Context) bool {
+	if ctx.App.Schedule != nil {
+		return true
+	}
+	for _, model := range ctx.Models {
+		if model.Schedule != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateSchedules saves the deployed config and (re-)registers all of the app's schedules
+func UpdateSchedules(ctx *context.Context, envName string, configZip []byte) error {
+	if !HasSchedule(ctx) {
+		removeSchedules(ctx.App.Name)
+		return nil
+	}
+
+	deployment := &scheduledDeployment{
+		Environment: envName,
+		ConfigZip:   configZip,
+	}
+	err := config.AWS.UploadMsgpackToS3(deployment, scheduledDeploymentKey(ctx.App.Name))
+	if err != nil {
+		return errors.Wrap(err, ctx.App.Name, "upload scheduled deployment")
+	}
+
+	return registerSchedules(ctx)
+}
+
+func registerSchedules(ctx *context.Context) error {
+	removeSchedules(ctx.App.Name)
+
+	if !HasSchedule(ctx) {
+		return nil
+	}
+
+	lastRuns, err := getLastScheduledRuns(ctx.App.Name)
+	if err != nil {
+		return err
+	}
+
+	appName := ctx.App.Name
+	var schedules []*appSchedule
+
+	addSchedule := func(resourceType resource.Type, resourceName string, spec string) error {
+		statusKey := scheduleStatusKey(resourceType, resourceName)
+		entryID, err := scheduleCron.AddFunc(spec, func() {
+			runScheduledRefresh(appName, statusKey)
+		})
+		if err != nil {
+			return errors.Wrap(userconfig.ErrorInvalidCronSchedule(spec, err), appName, resourceName, userconfig.ScheduleKey)
+		}
+		var lastRun *time.Time
+		if t, ok := lastRuns[statusKey]; ok {
+			lastRun = &t
+		}
+		schedules = append(schedules, &appSchedule{
+			entryID: entryID,
+			status: &resource.ScheduleStatus{
+				ResourceName: resourceName,
+				ResourceType: resourceType,
+				Schedule:     spec,
+				LastRun:      lastRun,
+			},
+		})
+		return nil
+	}
+
+	if ctx.App.Schedule != nil {
+		if err := addSchedule(resource.AppType, appName, *ctx.App.Schedule); err != nil {
+			removeEntries(schedules)
+			return err
+		}
+	}
+	for _, model := range ctx.Models {
+		if model.Schedule == nil {
+			continue
+		}
+		if err := addSchedule(resource.ModelType, model.Name, *model.Schedule); err != nil {
+			removeEntries(schedules)
+			return err
+		}
+	}
+
+	appSchedules.Lock()
+	defer appSchedules.Unlock()
+	appSchedules.m[appName] = schedules
+	return nil
+}
+
+func removeSchedules(appName string) {
+	appSchedules.Lock()
+	defer appSchedules.Unlock()
+	removeEntries(appSchedules.m[appName])
+	delete(appSchedules.m, appName)
+}
+
+func removeEntries(schedules []*appSchedule) {
+	for _, schedule := range schedules {
+		scheduleCron.Remove(schedule.entryID)
+	}
+}
+
+func GetScheduleStatuses(appName string) []*resource.ScheduleStatus {
+	appSchedules.Lock()
+	defer appSchedules.Unlock()
+
+	statuses := make([]*resource.ScheduleStatus, len(appSchedules.m[appName]))
+	for i, schedule := range appSchedules.m[appName] {
+		status := *schedule.status
+		if entry := scheduleCron.Entry(schedule.entryID); entry.Valid() && !entry.Next.IsZero() {
+			next := entry.Next
+			status.NextRun = &next
+		}
+		statuses[i] = &status
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].ResourceType != statuses[j].ResourceType {
+			return statuses[i].ResourceType < statuses[j].ResourceType
+		}
+		return statuses[i].ResourceName < statuses[j].ResourceName
+	})
+	return statuses
+}
+
+func updateScheduleStatus(appName string, statusKey string, fn func(*resource.ScheduleStatus)) {
+	appSchedules.Lock()
+	defer appSchedules.Unlock()
+	for _, schedule := range appSchedules.m[appName] {
+		if scheduleStatusKey(schedule.status.ResourceType, schedule.status.ResourceName) == statusKey {
+			fn(schedule.status)
+		}
+	}
+}
+
+func getLastScheduledRuns(appName string) (map[string]time.Time, error) {
+	lastRuns := make(map[string]time.Time)
+	err := config.AWS.ReadJSONFromS3(&lastRuns, scheduleLastRunsKey(appName))
+	if aws.IsNoSuchKeyErr(err) {
+		return lastRuns, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, appName, "download last scheduled runs")
+	}
+	return lastRuns, nil
+}
+
+func uploadLastScheduledRun(appName string, statusKey string, lastRun time.Time) error {
+	lastRuns, err := getLastScheduledRuns(appName)
+	if err != nil {
+		return err
+	}
+	lastRuns[statusKey] = lastRun
+	err = config.AWS.UploadJSONToS3(lastRuns, scheduleLastRunsKey(appName))
+	if err != nil {
+		return errors.Wrap(err, appName, "upload last scheduled runs")
+	}
+	return nil
+}
+
+func startScheduledRun(appName string) bool {
+	scheduledRunsInProgress.Lock()
+	defer scheduledRunsInProgress.Unlock()
+	if scheduledRunsInProgress.m[appName] {
+		return false
+	}
+	scheduledRunsInProgress.m[appName] = true
+	return true
+}
+
+func endScheduledRun(appName string) {
+	scheduledRunsInProgress.Lock()
+	defer scheduledRunsInProgress.Unlock()
+	delete(scheduledRunsInProgress.m, appName)
+}
+
+// runScheduledRefresh redeploys the app with a new dataset version (like `cortex refresh`),
+// unless a previous run (scheduled or not) is still in progress
+func runScheduledRefresh(appName string, statusKey string) {
+	now := time.Now()
+
+	if !startScheduledRun(appName) {
+		updateScheduleStatus(appName, statusKey, func(status *resource.ScheduleStatus) {
+			status.LastSkippedRun = &now
+		})
+		return
+	}
+	defer endScheduledRun(appName)
+
+	started, err := refreshApp(appName)
+	if err != nil {
+		err = errors.Wrap(err, appName, "scheduled run")
+		config.Telemetry.ReportError(err)
+		errors.PrintError(err)
+		return
+	}
+
+	if !started {
+		updateScheduleStatus(appName, statusKey, func(status *resource.ScheduleStatus) {
+			status.LastSkippedRun = &now
+		})
+		return
+	}
+
+	updateScheduleStatus(appName, statusKey, func(status *resource.ScheduleStatus) {
+		status.LastRun = &now
+	})
+	if err := uploadLastScheduledRun(appName, statusKey, now); err != nil {
+		config.Telemetry.ReportError(err)
+		errors.PrintError(err)
+	}
+}
+
+func refreshApp(appName string) (bool, error) {
+	existingWf, err := GetWorkflow(appName)
+	if err != nil {
+		return false, err
+	}
+	if argo.IsRunning(existingWf) {
+		return false, nil
+	}
+
+	var deployment scheduledDeployment
+	if err := config.AWS.ReadMsgpackFromS3(&deployment, scheduledDeploymentKey(appName)); err != nil {
+		return false, errors.Wrap(err, "download scheduled deployment")
+	}
+
+	zipContents, err := zip.UnzipMemToMem(deployment.ConfigZip)
+	if err != nil {
+		return false, errors.Wrap(err, "config.zip")
+	}
+
+	userconf, err := userconfig.New(zipContents, deployment.Environment)
+	if err != nil {
+		return false, err
+	}
+
+	ctx, err := ocontext.New(userconf, zipContents, true)
+	if err != nil {
+		return false, err
+	}
+
+	wf, err := Create(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	err = config.AWS.UploadMsgpackToS3(ctx.ToSerial(), ctx.Key)
+	if err != nil {
+		return false, errors.Wrap(err, "upload context")
+	}
+
+	err = Run(wf, ctx, existingWf)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1b1 "k8s.io/api/apps/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/api/context"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+)
+
+const (
+	tensorBoardContainerName = "tensorboard"
+
+	// TensorBoard is served through the APIs ingress, whose access logs show when it was last viewed
+	apisIngressLabelKey, apisIngressLabelValue = "app.kubernetes.io/name", "nginx-backend-apis"
+	apisIngressContainerName                   = "nginx-controller"
+)
+
+var tensorBoardAccessesCheckedAt time.Time
+
+func tensorBoardSpec(ctx *context.Context, model *context.Model) *k8s.DeploymentSpec {
+	env := append(k8s.AWSCredentials(), corev1.EnvVar{
+		Name:  "AWS_REGION",
+		Value: config.AWS.Region,
+	})
+
+	return &k8s.DeploymentSpec{
+		Name:     internalTensorBoardName(model.Name, ctx.App.Name),
+		Replicas: 1,
+		Labels: map[string]string{
+			"appName":      ctx.App.Name,
+			"workloadType": WorkloadTypeTensorBoard,
+			"modelName":    model.Name,
+			"resourceID":   model.ID,
+			"lastStarted":  strconv.FormatInt(time.Now().Unix(), 10),
+		},
+		Selector: map[string]string{
+			"appName":      ctx.App.Name,
+			"workloadType": WorkloadTypeTensorBoard,
+			"modelName":    model.Name,
+		},
+		PodSpec: k8s.PodSpec{
+			Labels: map[string]string{
+				"appName":      ctx.App.Name,
+				"workloadType": WorkloadTypeTensorBoard,
+				"modelName":    model.Name,
+				"resourceID":   model.ID,
+			},
+			K8sPodSpec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:            tensorBoardContainerName,
+						Image:           config.Cortex.TFTrainImage,
+						ImagePullPolicy: "Always",
+						Command:         []string{"tensorboard"},
+						Args: []string{
+							"--logdir=" + config.AWS.S3Path(model.SummariesDir),
+							"--host=0.0.0.0",
+							"--port=" + tensorBoardPortStr,
+							"--path_prefix=" + TensorBoardPath(model.Name, ctx.App.Name),
+						},
+						Env: env,
+						ReadinessProbe: &corev1.Probe{
+							InitialDelaySeconds: 5,
+							TimeoutSeconds:      5,
+							PeriodSeconds:       5,
+							SuccessThreshold:    1,
+							FailureThreshold:    2,
+							Handler: corev1.Handler{
+								TCPSocket: &corev1.TCPSocketAction{
+									Port: intstr.IntOrString{
+										IntVal: tensorBoardPortInt32,
+									},
+								},
+							},
+						},
+					},
+				},
+				ServiceAccountName: "default",
+			},
+		},
+		Namespace: config.Cortex.Namespace,
+	}
+}
+
+func tensorBoardIngressSpec(ctx *context.Context, modelName string) *k8s.IngressSpec {
+	return &k8s.IngressSpec{
+		Name:         internalTensorBoardName(modelName, ctx.App.Name),
+		ServiceName:  internalTensorBoardName(modelName, ctx.App.Name),
+		ServicePort:  tensorBoardPortInt32,
+		Path:         TensorBoardPath(modelName, ctx.App.Name),
+		IngressClass: "apis",
+		Labels: map[string]string{
+			"appName":      ctx.App.Name,
+			"workloadType": WorkloadTypeTensorBoard,
+			"modelName":    modelName,
+		},
+		Namespace: config.Cortex.Namespace,
+	}
+}
+
+func tensorBoardServiceSpec(ctx *context.Context, modelName string) *k8s.ServiceSpec {
+	return &k8s.ServiceSpec{
+		Name:       internalTensorBoardName(modelName, ctx.App.Name),
+		Port:       tensorBoardPortInt32,
+		TargetPort: tensorBoardPortInt32,
+		Labels: map[string]string{
+			"appName":      ctx.App.Name,
+			"workloadType": WorkloadTypeTensorBoard,
+			"modelName":    modelName,
+		},
+		Selector: map[string]string{
+			"appName":      ctx.App.Name,
+			"workloadType": WorkloadTypeTensorBoard,
+			"modelName":    modelName,
+		},
+		Namespace: config.Cortex.Namespace,
+	}
+}
+
+// StartTensorBoard starts a TensorBoard deployment for the model (or resets the idle timeout of the running one), and returns its URL
+func StartTensorBoard(ctx *context.Context, modelName string) (string, error) {
+	model := ctx.Models[modelName]
+	if model.SummariesDir == "" {
+		return "", ErrorTensorBoardExternalModel(modelName)
+	}
+
+	name := internalTensorBoardName(modelName, ctx.App.Name)
+
+	deployment, err := config.Kubernetes.GetDeployment(name)
+	if err != nil {
+		return "", errors.Wrap(err, ctx.App.Name, "tensorboard", modelName)
+	}
+
+	if deployment == nil {
+		_, err = config.Kubernetes.CreateDeployment(tensorBoardSpec(ctx, model))
+	} else if deployment.Labels["resourceID"] == model.ID {
+		deployment.Labels["lastStarted"] = strconv.FormatInt(time.Now().Unix(), 10)
+		_, err = config.Kubernetes.UpdateDeployment(deployment)
+	} else {
+		// The model was retrained since TensorBoard was started
+		updatedDeployment := k8s.Deployment(tensorBoardSpec(ctx, model))
+		updatedDeployment.ResourceVersion = deployment.ResourceVersion
+		_, err = config.Kubernetes.UpdateDeployment(updatedDeployment)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, ctx.App.Name, "tensorboard", modelName)
+	}
+
+	ingressExists, err := config.Kubernetes.IngressExists(name)
+	if err != nil {
+		return "", errors.Wrap(err, ctx.App.Name, "ingresses", name, "create")
+	}
+	if !ingressExists {
+		_, err = config.Kubernetes.CreateIngress(tensorBoardIngressSpec(ctx, modelName))
+		if err != nil {
+			return "", errors.Wrap(err, ctx.App.Name, "ingresses", name, "create")
+		}
+	}
+
+	serviceExists, err := config.Kubernetes.ServiceExists(name)
+	if err != nil {
+		return "", errors.Wrap(err, ctx.App.Name, "services", name, "create")
+	}
+	if !serviceExists {
+		_, err = config.Kubernetes.CreateService(tensorBoardServiceSpec(ctx, modelName))
+		if err != nil {
+			return "", errors.Wrap(err, ctx.App.Name, "services", name, "create")
+		}
+	}
+
+	baseURL, err := APIsBaseURL()
+	if err != nil {
+		return "", err
+	}
+	return baseURL + TensorBoardPath(modelName, ctx.App.Name) + "/", nil
+}
+
+// StopTensorBoard returns false if TensorBoard was not running for the model
+func StopTensorBoard(appName string, modelName string) bool {
+	name := internalTensorBoardName(modelName, appName)
+	config.Kubernetes.DeleteIngress(name)
+	config.Kubernetes.DeleteService(name)
+	wasRunning, _ := config.Kubernetes.DeleteDeployment(name)
+	return wasRunning
+}
+
+// DeleteExpiredTensorBoards stops TensorBoards which haven't been viewed (or started again) for tensorBoardIdleTimeout
+func DeleteExpiredTensorBoards() error {
+	deployments, err := config.Kubernetes.ListDeploymentsByLabel("workloadType", WorkloadTypeTensorBoard)
+	if err != nil {
+		return errors.Wrap(err, "tensorboard")
+	}
+	if len(deployments) == 0 {
+		return nil
+	}
+
+	if time.Since(tensorBoardAccessesCheckedAt) >= tensorBoardAccessCheckInterval*time.Second {
+		err := updateTensorBoardAccessTimes(deployments)
+		if err != nil {
+			return errors.Wrap(err, "tensorboard")
+		}
+		tensorBoardAccessesCheckedAt = time.Now()
+	}
+
+	for _, deployment := range deployments {
+		lastActive, _ := strconv.ParseInt(deployment.Labels["lastStarted"], 10, 64)
+		lastAccessed, err := strconv.ParseInt(deployment.Annotations[lastAccessedAnnotation], 10, 64)
+		if err == nil && lastAccessed > lastActive {
+			lastActive = lastAccessed
+		}
+		if time.Now().Unix()-lastActive < tensorBoardIdleTimeout {
+			continue
+		}
+		StopTensorBoard(deployment.Labels["appName"], deployment.Labels["modelName"])
+	}
+	return nil
+}
+
+// updateTensorBoardAccessTimes sets the lastAccessed annotation of TensorBoards which were requested since the previous check
+// (the logs are read from twice the check interval ago, so that no requests are missed between checks)
+func updateTensorBoardAccessTimes(deployments []appsv1b1.Deployment) error {
+	ingressPods, err := config.Kubernetes.ListPodsByLabel(apisIngressLabelKey, apisIngressLabelValue)
+	if err != nil {
+		return err
+	}
+
+	var accessLogsBuilder strings.Builder
+	for _, pod := range ingressPods {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		logs, err := config.Kubernetes.GetPodLogs(pod.Name, apisIngressContainerName, 2*tensorBoardAccessCheckInterval)
+		if err != nil {
+			return err
+		}
+		accessLogsBuilder.WriteString(logs)
+	}
+	accessLogs := accessLogsBuilder.String()
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	for i := range deployments {
+		deployment := &deployments[i]
+		// access log lines contain the request, e.g. "GET /<app>/tensorboard/<model>/data/runs HTTP/1.1"
+		path := TensorBoardPath(deployment.Labels["modelName"], deployment.Labels["appName"]) + "/"
+		if !strings.Contains(accessLogs, " "+path) {
+			continue
+		}
+		if deployment.Annotations == nil {
+			deployment.Annotations = map[string]string{}
+		}
+		deployment.Annotations[lastAccessedAnnotation] = now
+		if _, err := config.Kubernetes.UpdateDeployment(deployment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TensorBoardPath(modelName string, appName string) string {
+	return "/" + appName + "/tensorboard/" + modelName
+}
+
+func internalTensorBoardName(modelName string, appName string) string {
+	return appName + "----tensorboard----" + modelName
+}
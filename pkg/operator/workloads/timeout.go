@@ -0,0 +1,66 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/k8s"
+	"github.com/cortexlabs/cortex/pkg/lib/spark"
+	"github.com/cortexlabs/cortex/pkg/operator/api/resource"
+	"github.com/cortexlabs/cortex/pkg/operator/config"
+)
+
+// TerminateTimedOutWorkloads sets the status of workloads which exceeded their max_duration, and deletes them.
+// Training jobs are terminated by Kubernetes (via activeDeadlineSeconds), but Spark applications must be terminated here.
+func TerminateTimedOutWorkloads() error {
+	jobs, err := config.Kubernetes.ListJobsByLabel("workloadType", workloadTypeTrain)
+	if err != nil {
+		return errors.Wrap(err, "timeouts")
+	}
+	for _, job := range jobs {
+		if !k8s.JobTimedOut(&job) {
+			continue
+		}
+		if err := endDataWorkload(job.Name, job.Labels["appName"], resource.ExitCodeDataTimedOut); err != nil {
+			return err
+		}
+		config.Kubernetes.DeleteJob(job.Name)
+	}
+
+	sparkApps, err := config.Spark.ListByLabel("workloadType", workloadTypeData)
+	if err != nil {
+		return errors.Wrap(err, "timeouts")
+	}
+	for _, sparkApp := range sparkApps {
+		maxDurationSecs, err := strconv.ParseInt(sparkApp.Annotations[maxDurationAnnotation], 10, 64)
+		if err != nil || spark.IsDone(&sparkApp) {
+			continue
+		}
+		if time.Since(sparkApp.CreationTimestamp.Time) < time.Duration(maxDurationSecs)*time.Second {
+			continue
+		}
+		if err := endDataWorkload(sparkApp.Name, sparkApp.Labels["appName"], resource.ExitCodeDataTimedOut); err != nil {
+			return err
+		}
+		config.Spark.Delete(sparkApp.Name)
+	}
+
+	return nil
+}
@@ -23,8 +23,10 @@ import (
 
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/argo"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/k8s"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	s "github.com/cortexlabs/cortex/pkg/lib/strings"
 	"github.com/cortexlabs/cortex/pkg/operator/api/context"
 	"github.com/cortexlabs/cortex/pkg/operator/api/userconfig"
 	"github.com/cortexlabs/cortex/pkg/operator/config"
@@ -35,8 +37,20 @@ func trainingJobSpec(
 	modelID string,
 	workloadID string,
 	tfCompute *userconfig.TFCompute,
+	maxRetries int32,
+	fold *int, // nil for the full-data fit
 ) *batchv1.Job {
 
+	args := []string{
+		"--workload-id=" + workloadID,
+		"--context=" + config.AWS.S3Path(ctx.Key),
+		"--cache-dir=" + consts.ContextCacheDir,
+		"--model=" + modelID,
+	}
+	if fold != nil {
+		args = append(args, "--fold="+s.Int(*fold))
+	}
+
 	resourceList := corev1.ResourceList{}
 	limitsList := corev1.ResourceList{}
 	if tfCompute.CPU != nil {
@@ -46,15 +60,23 @@ func trainingJobSpec(
 		resourceList[corev1.ResourceMemory] = tfCompute.Mem.Quantity
 	}
 
-	trainImage := config.Cortex.TFTrainImage
+	model := ctx.Models.OneByID(modelID)
+	framework := config.Cortex.Frameworks[model.Framework]
+
+	trainImage := framework.TrainImage
 	if tfCompute.GPU != nil {
-		trainImage = config.Cortex.TFTrainImageGPU
+		trainImage = framework.TrainImageGPU
 		resourceList["nvidia.com/gpu"] = *k8sresource.NewQuantity(*tfCompute.GPU, k8sresource.DecimalSI)
 		limitsList["nvidia.com/gpu"] = *k8sresource.NewQuantity(*tfCompute.GPU, k8sresource.DecimalSI)
 	}
+	if model.Image != nil {
+		trainImage = *model.Image
+	}
 
 	spec := k8s.Job(&k8s.JobSpec{
-		Name: workloadID,
+		Name:         workloadID,
+		BackoffLimit: maxRetries,
+		MaxDuration:  userconfig.DurationSecs(model.MaxDuration),
 		Labels: map[string]string{
 			"appName":      ctx.App.Name,
 			"workloadType": workloadTypeTrain,
@@ -74,14 +96,9 @@ func trainingJobSpec(
 						Name:            "train",
 						Image:           trainImage,
 						ImagePullPolicy: "Always",
-						Args: []string{
-							"--workload-id=" + workloadID,
-							"--context=" + config.AWS.S3Path(ctx.Key),
-							"--cache-dir=" + consts.ContextCacheDir,
-							"--model=" + modelID,
-						},
-						Env:          k8s.AWSCredentials(),
-						VolumeMounts: k8s.DefaultVolumeMounts(),
+						Args:            args,
+						Env:             k8s.AWSCredentials(),
+						VolumeMounts:    k8s.DefaultVolumeMounts(),
 						Resources: corev1.ResourceRequirements{
 							Requests: resourceList,
 							Limits:   limitsList,
@@ -100,6 +117,8 @@ func trainingJobSpec(
 
 func trainingWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 	modelsToTrain := make(map[string]*userconfig.TFCompute)
+	modelFolds := make(map[string]int)
+	modelMaxRetries := make(map[string]int32)
 	for _, model := range ctx.Models {
 		modelCached, err := checkResourceCached(model, ctx)
 		if err != nil {
@@ -109,6 +128,18 @@ func trainingWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 			continue
 		}
 
+		if model.IsExternal() {
+			exportExists, err := config.AWS.IsS3aPrefixExternal(model.ExternalModel.Path)
+			if err != nil || !exportExists {
+				return nil, errors.Wrap(ErrorUserDataUnavailable(model.ExternalModel.Path), ctx.App.Name, userconfig.Identify(model), userconfig.ExternalModelKey, userconfig.PathKey)
+			}
+		}
+
+		modelFolds[model.ID] = model.NumFolds()
+		if maxRetries := int32(model.Retry.MaxRetries); maxRetries > modelMaxRetries[model.ID] {
+			modelMaxRetries[model.ID] = maxRetries
+		}
+
 		if tfCompute, ok := modelsToTrain[model.ID]; ok {
 			modelsToTrain[model.ID] = userconfig.MaxTFCompute(tfCompute, model.Compute)
 		} else {
@@ -118,15 +149,36 @@ func trainingWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 
 	var workloadSpecs []*WorkloadSpec
 	for modelID, tfCompute := range modelsToTrain {
+		maxRetries := modelMaxRetries[modelID]
+
+		// Each cross-validation fold is trained in its own workload before the full-data fit
+		foldWorkloadIDs := strset.New()
+		for i := 0; i < modelFolds[modelID]; i++ {
+			fold := i
+			foldWorkloadID := generateWorkloadID()
+			foldWorkloadIDs.Add(foldWorkloadID)
+			workloadSpecs = append(workloadSpecs, &WorkloadSpec{
+				WorkloadID:            foldWorkloadID,
+				ResourceIDs:           strset.New(),
+				DependencyResourceIDs: ctx.AllComputedResourceDependencies(modelID),
+				Spec:                  trainingJobSpec(ctx, modelID, foldWorkloadID, tfCompute, maxRetries, &fold),
+				K8sAction:             "create",
+				SuccessCondition:      k8s.JobSuccessCondition,
+				FailureCondition:      k8s.JobFailureConditionWithRetries(maxRetries),
+				WorkloadType:          workloadTypeTrain,
+			})
+		}
+
 		workloadID := generateWorkloadID()
 		workloadSpecs = append(workloadSpecs, &WorkloadSpec{
-			WorkloadID:       workloadID,
-			ResourceIDs:      strset.New(modelID),
-			Spec:             trainingJobSpec(ctx, modelID, workloadID, tfCompute),
-			K8sAction:        "create",
-			SuccessCondition: k8s.JobSuccessCondition,
-			FailureCondition: k8s.JobFailureCondition,
-			WorkloadType:     workloadTypeTrain,
+			WorkloadID:            workloadID,
+			ResourceIDs:           strset.New(modelID),
+			DependencyWorkloadIDs: foldWorkloadIDs,
+			Spec:                  trainingJobSpec(ctx, modelID, workloadID, tfCompute, maxRetries, nil),
+			K8sAction:             "create",
+			SuccessCondition:      k8s.JobSuccessCondition,
+			FailureCondition:      k8s.JobFailureConditionWithRetries(maxRetries),
+			WorkloadType:          workloadTypeTrain,
 		})
 	}
 
@@ -26,6 +26,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/argo"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	"github.com/cortexlabs/cortex/pkg/lib/slices"
 	"github.com/cortexlabs/cortex/pkg/operator/api/context"
 	"github.com/cortexlabs/cortex/pkg/operator/config"
@@ -45,9 +46,14 @@ func Init() error {
 			config.Argo.Delete(wf.Name)
 		} else {
 			setCurrentContext(ctx)
+			if err := registerSchedules(ctx); err != nil {
+				errors.PrintError(err)
+			}
 		}
 	}
 
+	scheduleCron.Start()
+
 	return nil
 }
 
@@ -99,14 +105,17 @@ func Create(ctx *context.Context) (*awfv1.Workflow, error) {
 
 	for _, spec := range allSpecs {
 		var dependencyWorkloadIDs []string
+		dependencyResourceIDs := strset.Union(spec.DependencyResourceIDs)
 		for resourceID := range spec.ResourceIDs {
-			for dependencyResourceID := range ctx.AllComputedResourceDependencies(resourceID) {
-				workloadID := resourceWorkloadIDs[dependencyResourceID]
-				if workloadID != "" && workloadID != spec.WorkloadID {
-					dependencyWorkloadIDs = append(dependencyWorkloadIDs, workloadID)
-				}
+			dependencyResourceIDs.Merge(ctx.AllComputedResourceDependencies(resourceID))
+		}
+		for dependencyResourceID := range dependencyResourceIDs {
+			workloadID := resourceWorkloadIDs[dependencyResourceID]
+			if workloadID != "" && workloadID != spec.WorkloadID {
+				dependencyWorkloadIDs = append(dependencyWorkloadIDs, workloadID)
 			}
 		}
+		dependencyWorkloadIDs = append(dependencyWorkloadIDs, spec.DependencyWorkloadIDs.Slice()...)
 
 		manifest, err := json.Marshal(spec.Spec)
 		if err != nil {
@@ -243,6 +252,7 @@ func DeleteApp(appName string, keepCache bool) bool {
 		config.Kubernetes.DeletePod(pod.Name)
 	}
 
+	removeSchedules(appName)
 	deleteCurrentContext(appName)
 	uncacheDataSavedStatuses(nil, appName)
 	uncacheLatestWorkloadIDs(nil, appName)
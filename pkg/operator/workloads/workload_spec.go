@@ -19,6 +19,7 @@ package workloads
 import (
 	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -34,13 +35,15 @@ import (
 )
 
 type WorkloadSpec struct {
-	WorkloadID       string
-	ResourceIDs      strset.Set
-	Spec             metav1.Object
-	K8sAction        string
-	SuccessCondition string
-	FailureCondition string
-	WorkloadType     string
+	WorkloadID            string
+	ResourceIDs           strset.Set
+	DependencyResourceIDs strset.Set // resources which must be computed first, in addition to the dependencies of ResourceIDs
+	DependencyWorkloadIDs strset.Set // workloads in the same workflow which must complete first
+	Spec                  metav1.Object
+	K8sAction             string
+	SuccessCondition      string
+	FailureCondition      string
+	WorkloadType          string
 }
 
 type SavedWorkloadSpec struct {
@@ -91,7 +94,6 @@ func getSavedWorkloadSpec(workloadID string, appName string) (*SavedWorkloadSpec
 
 func UpdateDataWorkflowErrors(failedPods []corev1.Pod) error {
 	checkedWorkloadIDs := strset.New()
-	nowTime := pointer.Time(time.Now())
 
 	for _, pod := range failedPods {
 		appName, ok := pod.Labels["appName"]
@@ -112,62 +114,88 @@ func UpdateDataWorkflowErrors(failedPods []corev1.Pod) error {
 		}
 		checkedWorkloadIDs.Add(workloadID)
 
-		savedWorkloadSpec, err := getSavedWorkloadSpec(workloadID, appName)
-		if err != nil {
-			return err
-		}
-		if savedWorkloadSpec == nil {
-			continue
+		if pod.Labels["workloadType"] == workloadTypeTrain {
+			job, err := config.Kubernetes.GetJob(workloadID)
+			if err != nil {
+				return err
+			}
+			// Timed out jobs are handled by TerminateTimedOutWorkloads
+			if isJobRetrying(job) || (job != nil && k8s.JobTimedOut(job)) {
+				continue
+			}
 		}
 
-		resourceWorkloadIDs := make(map[string]string, len(savedWorkloadSpec.Resources))
-		for _, resource := range savedWorkloadSpec.Resources {
-			resourceWorkloadIDs[resource.ID] = workloadID
+		var exitCode resource.DataExitCode
+		switch k8s.GetPodStatus(&pod) {
+		case k8s.PodStatusKilled:
+			exitCode = resource.ExitCodeDataKilled
+		case k8s.PodStatusKilledOOM:
+			exitCode = resource.ExitCodeDataOOM
+		default:
+			exitCode = resource.ExitCodeDataFailed
 		}
 
-		savedStatuses, err := getDataSavedStatuses(resourceWorkloadIDs, appName)
-		if err != nil {
+		if err := endDataWorkload(workloadID, appName, exitCode); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		var savedStatusesToUpload []*resource.DataSavedStatus
-		for resourceID, res := range savedWorkloadSpec.Resources {
-			savedStatus := savedStatuses[resourceID]
-
-			if savedStatus == nil {
-				savedStatus = &resource.DataSavedStatus{
-					BaseSavedStatus: resource.BaseSavedStatus{
-						ResourceID:   resourceID,
-						ResourceType: res.ResourceType,
-						WorkloadID:   workloadID,
-						AppName:      appName,
-					},
-				}
-			}
+// endDataWorkload sets the exit code of the workload's resources which haven't ended yet
+func endDataWorkload(workloadID string, appName string, exitCode resource.DataExitCode) error {
+	nowTime := pointer.Time(time.Now())
+
+	savedWorkloadSpec, err := getSavedWorkloadSpec(workloadID, appName)
+	if err != nil {
+		return err
+	}
+	if savedWorkloadSpec == nil {
+		return nil
+	}
+
+	resourceWorkloadIDs := make(map[string]string, len(savedWorkloadSpec.Resources))
+	for _, resource := range savedWorkloadSpec.Resources {
+		resourceWorkloadIDs[resource.ID] = workloadID
+	}
+
+	savedStatuses, err := getDataSavedStatuses(resourceWorkloadIDs, appName)
+	if err != nil {
+		return err
+	}
 
-			if savedStatus.End == nil {
-				savedStatus.End = nowTime
-				if savedStatus.Start == nil {
-					savedStatus.Start = nowTime
-				}
-
-				switch k8s.GetPodStatus(&pod) {
-				case k8s.PodStatusKilled:
-					savedStatus.ExitCode = resource.ExitCodeDataKilled
-				case k8s.PodStatusKilledOOM:
-					savedStatus.ExitCode = resource.ExitCodeDataOOM
-				default:
-					savedStatus.ExitCode = resource.ExitCodeDataFailed
-				}
-
-				savedStatusesToUpload = append(savedStatusesToUpload, savedStatus)
+	var savedStatusesToUpload []*resource.DataSavedStatus
+	for resourceID, res := range savedWorkloadSpec.Resources {
+		savedStatus := savedStatuses[resourceID]
+
+		if savedStatus == nil {
+			savedStatus = &resource.DataSavedStatus{
+				BaseSavedStatus: resource.BaseSavedStatus{
+					ResourceID:   resourceID,
+					ResourceType: res.ResourceType,
+					WorkloadID:   workloadID,
+					AppName:      appName,
+				},
 			}
 		}
 
-		err = uploadDataSavedStatuses(savedStatusesToUpload)
-		if err != nil {
-			return err
+		if savedStatus.End == nil {
+			savedStatus.End = nowTime
+			if savedStatus.Start == nil {
+				savedStatus.Start = nowTime
+			}
+			savedStatus.ExitCode = exitCode
+			savedStatusesToUpload = append(savedStatusesToUpload, savedStatus)
 		}
 	}
-	return nil
+
+	return uploadDataSavedStatuses(savedStatusesToUpload)
+}
+
+// isJobRetrying returns true if the job will create another pod after a pod failure
+func isJobRetrying(job *batchv1.Job) bool {
+	if job == nil || job.Spec.BackoffLimit == nil || *job.Spec.BackoffLimit == 0 {
+		return false
+	}
+	return job.Status.Failed <= *job.Spec.BackoffLimit
 }
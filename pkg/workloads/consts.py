@@ -20,6 +20,9 @@ COLUMN_TYPE_STRING = "STRING_COLUMN"
 COLUMN_TYPE_INT_LIST = "INT_LIST_COLUMN"
 COLUMN_TYPE_FLOAT_LIST = "FLOAT_LIST_COLUMN"
 COLUMN_TYPE_STRING_LIST = "STRING_LIST_COLUMN"
+COLUMN_TYPE_BOOL = "BOOL_COLUMN"
+COLUMN_TYPE_TIMESTAMP = "TIMESTAMP_COLUMN"
+COLUMN_TYPE_DATE = "DATE_COLUMN"
 
 COLUMN_LIST_TYPES = [COLUMN_TYPE_INT_LIST, COLUMN_TYPE_FLOAT_LIST, COLUMN_TYPE_STRING_LIST]
 
@@ -30,6 +33,9 @@ COLUMN_TYPES = [
     COLUMN_TYPE_INT_LIST,
     COLUMN_TYPE_FLOAT_LIST,
     COLUMN_TYPE_STRING_LIST,
+    COLUMN_TYPE_BOOL,
+    COLUMN_TYPE_TIMESTAMP,
+    COLUMN_TYPE_DATE,
 ]
 
 VALUE_TYPE_INT = "INT"
@@ -38,3 +44,7 @@ VALUE_TYPE_STRING = "STRING"
 VALUE_TYPE_BOOL = "BOOL"
 
 VALUE_TYPES = [VALUE_TYPE_INT, VALUE_TYPE_FLOAT, VALUE_TYPE_STRING, VALUE_TYPE_BOOL]
+
+CLASS_WEIGHT_BALANCED = "balanced"
+CLASS_WEIGHT_AGGREGATOR = "cortex.class_distribution_int"
+EXAMPLE_WEIGHT_COLUMN = "cortex_example_weight"
@@ -79,7 +79,9 @@ class Context:
         self.constants = self.ctx["constants"]
         self.models = self.ctx["models"]
         self.apis = self.ctx["apis"]
-        self.training_datasets = {k: v["dataset"] for k, v in self.models.items()}
+        self.training_datasets = {
+            k: v["dataset"] for k, v in self.models.items() if v["dataset"] is not None
+        }
 
         self.api_version = self.cortex_config["api_version"]
 
@@ -198,6 +200,13 @@ class Context:
 
     def get_aggregator_impl(self, column_name):
         aggregator_name = self.aggregates[column_name]["aggregator"]
+        try:
+            return self.get_aggregator_impl_by_name(aggregator_name)
+        except CortexException as e:
+            e.wrap("aggregate " + column_name)
+            raise
+
+    def get_aggregator_impl_by_name(self, aggregator_name):
         if aggregator_name in self._aggregator_impls:
             return self._aggregator_impls[aggregator_name]
         aggregator = self.aggregators[aggregator_name]
@@ -211,13 +220,13 @@ class Context:
                 module_prefix, aggregator["name"], aggregator["impl_key"]
             )
         except CortexException as e:
-            e.wrap("aggregate " + column_name, "aggregator")
+            e.wrap("aggregator")
             raise
 
         try:
             _validate_impl(impl, AGGREGATOR_IMPL_VALIDATION)
         except CortexException as e:
-            e.wrap("aggregate " + column_name, "aggregator " + aggregator["name"])
+            e.wrap("aggregator " + aggregator["name"])
             raise
 
         self._aggregator_impls[aggregator_name] = (impl, impl_path)
@@ -272,21 +281,59 @@ class Context:
         return impl
 
     # Mode must be "training" or "evaluation"
-    def get_training_data_parts(self, model_name, mode, part_prefix="part"):
+    # For k-fold cross-validation, fold is the index of the held-out fold (or None for the full-data fit)
+    def get_training_data_parts(self, model_name, mode, part_prefix="part", fold=None):
         training_dataset = self.models[model_name]["dataset"]
-        if mode == "training":
-            data_key = training_dataset["train_key"]
-        elif mode == "evaluation":
-            data_key = training_dataset["eval_key"]
-        else:
+        if mode not in ("training", "evaluation"):
             raise CortexException(
                 "unrecognized training/evaluation mode {} must be one of (train_key, eval_key)".format(
                     mode
                 )
             )
 
-        training_data_parts_prefix = os.path.join(data_key, part_prefix)
-        return self.storage.search(prefix=training_data_parts_prefix)
+        if training_dataset["fold_keys"]:
+            fold_keys = training_dataset["fold_keys"]
+            if fold is None:
+                data_keys = fold_keys
+            elif mode == "training":
+                data_keys = [key for i, key in enumerate(fold_keys) if i != fold]
+            else:
+                data_keys = [fold_keys[fold]]
+        elif mode == "training":
+            data_keys = [training_dataset["train_key"]]
+        else:
+            data_keys = [training_dataset["eval_key"]]
+
+        training_data_parts = []
+        for data_key in data_keys:
+            training_data_parts_prefix = os.path.join(data_key, part_prefix)
+            training_data_parts += self.storage.search(prefix=training_data_parts_prefix)
+        return training_data_parts
+
+    def get_target_column_names(self, model_name):
+        model = self.models[model_name]
+        if model.get("targets"):
+            return [target["column"] for target in model["targets"]]
+        if model["target_column"]:
+            return [model["target_column"]]
+        return []
+
+    def get_training_column_names(self, model_name):
+        """Returns the columns which are only used during training (training_columns and weight_column)"""
+        model = self.models[model_name]
+        column_names = list(model["training_columns"])
+        weight_column = model["weight_column"]
+        if (
+            weight_column
+            and weight_column not in column_names
+            and weight_column not in model["feature_columns"]
+            and weight_column not in self.get_target_column_names(model_name)
+        ):
+            column_names.append(weight_column)
+        return column_names
+
+    def is_multi_target(self, model_name):
+        return bool(self.models[model_name].get("targets"))
 
     def column_config(self, column_name):
         if self.is_raw_column(column_name):
@@ -352,9 +399,12 @@ class Context:
             "type",
             "path",
             "target_column",
+            "targets",
             "prediction_key",
             "feature_columns",
             "training_columns",
+            "weight_column",
+            "class_weight",
             "hparams",
             "data_partition_ratio",
             "aggregates",
@@ -371,6 +421,16 @@ class Context:
             model_config["training_columns"][i] = self.column_config(column_name)
 
         model_config["target_column"] = self.column_config(model_config["target_column"])
+        model_config["weight_column"] = self.column_config(model_config["weight_column"])
+
+        # the feature which holds each example's weight (to be passed to the estimator's weight_column)
+        model_config["example_weight_column"] = None
+        if model["weight_column"] or model["class_weight"] is not None:
+            model_config["example_weight_column"] = consts.EXAMPLE_WEIGHT_COLUMN
+
+        if model_config["targets"]:
+            for target in model_config["targets"]:
+                target["column"] = self.column_config(target["column"])
 
         aggregates_dict = {key: key for key in model_config["aggregates"]}
         model_config["aggregates"] = self.populate_args(aggregates_dict)
@@ -434,6 +494,19 @@ class Context:
             }
             self.storage.put_json(status, key)
 
+    def upload_resource_status_attempt_start(self, resource, attempt, max_attempts):
+        key = self.resource_status_key(resource)
+        status = {
+            "resource_id": resource["id"],
+            "resource_type": resource["resource_type"],
+            "workload_id": resource["workload_id"],
+            "app_name": self.app["name"],
+            "start": util.now_timestamp_rfc_3339(),
+            "attempt": attempt,
+            "max_attempts": max_attempts,
+        }
+        self.storage.put_json(status, key)
+
     def upload_resource_status_no_op(self, *resources):
         timestamp = util.now_timestamp_rfc_3339()
         for resource in resources:
@@ -469,6 +542,9 @@ class Context:
     def resource_status_key(self, resource):
         return os.path.join(self.status_prefix, resource["id"], resource["workload_id"])
 
+    def quality_report_key(self, raw_column_id):
+        return os.path.join(self.raw_dataset["quality_reports_prefix"], raw_column_id + ".json")
+
 
 MODEL_IMPL_VALIDATION = {
     "required": [{"name": "create_estimator", "args": ["run_config", "model_config"]}],
@@ -551,14 +627,28 @@ def _deserialize_raw_ctx(raw_ctx):
         raw_columns["raw_int_columns"],
         raw_columns["raw_float_columns"],
         raw_columns["raw_string_columns"],
+        raw_columns["raw_bool_columns"],
+        raw_columns["raw_timestamp_columns"],
+        raw_columns["raw_date_columns"],
+        raw_columns["raw_int_list_columns"],
+        raw_columns["raw_float_list_columns"],
+        raw_columns["raw_string_list_columns"],
     )
 
-    data_split = raw_ctx["environment_data"]
-
-    if data_split["csv_data"] is not None and data_split["parquet_data"] is None:
-        raw_ctx["environment"]["data"] = data_split["csv_data"]
-    elif data_split["parquet_data"] is not None and data_split["csv_data"] is None:
-        raw_ctx["environment"]["data"] = data_split["parquet_data"]
-    else:
-        raise CortexException("expected csv_data or parquet_data but found " + data_split)
+    raw_ctx["environment"]["data"] = _collect_data(raw_ctx["environment_data"])
     return raw_ctx
+
+
+def _collect_data(data_split):
+    data_keys = ["csv_data", "parquet_data", "json_data", "avro_data", "jdbc_data", "join_data"]
+    datas = [data_split.get(key) for key in data_keys if data_split.get(key) is not None]
+    if len(datas) != 1:
+        raise CortexException(
+            "expected exactly one of {} but found {}".format(", ".join(data_keys), data_split)
+        )
+
+    data = datas[0]
+    if data["type"] == "join":
+        for source in data["sources"]:
+            source["data"] = _collect_data(source["data"])
+    return data
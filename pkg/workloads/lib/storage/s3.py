@@ -51,6 +51,7 @@ class S3(object):
     @staticmethod
     def deconstruct_s3_path(s3_path):
         path = util.remove_prefix_if_present(s3_path, "s3://")
+        path = util.remove_prefix_if_present(path, "s3a://")
         bucket = path.split("/")[0]
         key = os.path.join(*path.split("/")[1:])
         return (bucket, key)
@@ -131,10 +132,10 @@ class S3(object):
         self._upload_string_to_s3(json.dumps(obj), key)
 
     def get_json(self, key, allow_missing=False):
-        obj = self._read_bytes_from_s3(key, allow_missing).decode("utf-8")
+        obj = self._read_bytes_from_s3(key, allow_missing)
         if obj is None:
             return None
-        return json.loads(obj)
+        return json.loads(obj.decode("utf-8"))
 
     def put_msgpack(self, obj, key):
         self._upload_string_to_s3(msgpack.dumps(obj), key)
@@ -170,6 +171,9 @@ class S3(object):
         self.s3.upload_file("temp.zip", self.bucket, key)
         util.rm_file("temp.zip")
 
+    def download_dir(self, prefix, local_dir):
+        self._get_dir(prefix, local_dir)
+
     def download_and_unzip(self, key, local_dir):
         util.mkdir_p(local_dir)
         local_zip = os.path.join(local_dir, "zip.zip")
@@ -15,6 +15,7 @@
 import sys
 import os
 from copy import deepcopy
+from datetime import datetime, date
 import pytest
 
 from lib import util
@@ -165,6 +166,38 @@ def test_validate_column_type():
     assert util.validate_column_type("2", "STRING_LIST_COLUMN") == False
     assert util.validate_column_type(["2", "string"], "STRING_LIST_COLUMN") == True
 
+    assert util.validate_column_type(True, "BOOL_COLUMN") == True
+    assert util.validate_column_type(1, "BOOL_COLUMN") == False
+
+    assert util.validate_column_type(datetime(2019, 3, 1, 12, 30), "TIMESTAMP_COLUMN") == True
+    assert util.validate_column_type(date(2019, 3, 1), "TIMESTAMP_COLUMN") == False
+    assert util.validate_column_type("2019-03-01T12:30:00Z", "TIMESTAMP_COLUMN") == False
+
+    assert util.validate_column_type(date(2019, 3, 1), "DATE_COLUMN") == True
+    assert util.validate_column_type(datetime(2019, 3, 1, 12, 30), "DATE_COLUMN") == False
+    assert util.validate_column_type("2019-03-01", "DATE_COLUMN") == False
+
+
+def test_upcast_timestamp_and_date():
+    assert util.upcast("2019-03-01T12:30:00Z", "TIMESTAMP_COLUMN") == datetime(2019, 3, 1, 12, 30)
+    assert util.upcast("2019-03-01 12:30:00.5", "TIMESTAMP_COLUMN") == datetime(
+        2019, 3, 1, 12, 30, 0, 500000
+    )
+    assert util.upcast(0, "TIMESTAMP_COLUMN") == datetime(1970, 1, 1)
+    assert util.upcast("2019-03-01", "DATE_COLUMN") == date(2019, 3, 1)
+
+    assert util.CORTEX_TYPE_TO_UPCAST_VALIDATOR["TIMESTAMP_COLUMN"]("2019-03-01T12:30:00") == True
+    assert util.CORTEX_TYPE_TO_UPCAST_VALIDATOR["TIMESTAMP_COLUMN"]("March 1") == False
+    assert util.CORTEX_TYPE_TO_UPCAST_VALIDATOR["DATE_COLUMN"]("2019-03-01") == True
+    assert util.CORTEX_TYPE_TO_UPCAST_VALIDATOR["DATE_COLUMN"]("2019-03-01T12:30:00") == False
+
+    assert util.timestamp_to_epoch_seconds(datetime(1970, 1, 2)) == 86400
+    assert util.date_to_epoch_days(date(1970, 1, 11)) == 10
+    assert util.timestamp_to_epoch_seconds(86400) == 86400
+    assert util.timestamp_to_epoch_seconds(86400.5) == 86400.5
+    assert util.date_to_epoch_days(10) == 10
+    assert util.date_to_epoch_days(10.5) == 10.5
+
 
 def test_validate_value_type():
     assert util.validate_value_type(2, "INT") == True
@@ -27,9 +27,24 @@ CORTEX_TYPE_TO_TF_TYPE = {
     consts.COLUMN_TYPE_FLOAT_LIST: tf.float32,
     consts.COLUMN_TYPE_STRING: tf.string,
     consts.COLUMN_TYPE_STRING_LIST: tf.string,
+    consts.COLUMN_TYPE_BOOL: tf.int64,
+    consts.COLUMN_TYPE_TIMESTAMP: tf.int64,
+    consts.COLUMN_TYPE_DATE: tf.int64,
 }
 
 
+def to_tf_value(value, column_type):
+    """Encodes boolean, timestamp and date values as integers, matching how they are written to tfrecords
+    (timestamp and date values which are already numbers since the Unix epoch are passed through)"""
+    if column_type == consts.COLUMN_TYPE_BOOL:
+        return int(value)
+    if column_type == consts.COLUMN_TYPE_TIMESTAMP:
+        return util.timestamp_to_epoch_seconds(value)
+    if column_type == consts.COLUMN_TYPE_DATE:
+        return util.date_to_epoch_days(value)
+    return value
+
+
 def add_tf_types(config):
     if not util.is_dict(config):
         return
@@ -63,12 +78,12 @@ def get_column_tf_types(model_name, ctx, training=True):
     }
 
     if training:
-        target_column_name = model["target_column"]
-        column_types[target_column_name] = CORTEX_TYPE_TO_TF_TYPE[
-            ctx.columns[target_column_name]["type"]
-        ]
+        for target_column_name in ctx.get_target_column_names(model_name):
+            column_types[target_column_name] = CORTEX_TYPE_TO_TF_TYPE[
+                ctx.columns[target_column_name]["type"]
+            ]
 
-        for column_name in model["training_columns"]:
+        for column_name in ctx.get_training_column_names(model_name):
             column_types[column_name] = CORTEX_TYPE_TO_TF_TYPE[ctx.columns[column_name]["type"]]
 
     return column_types
@@ -26,8 +26,9 @@ import zipfile
 import hashlib
 import marshal
 import msgpack
+import calendar
 from copy import deepcopy
-from datetime import datetime
+from datetime import datetime, date
 
 import consts
 
@@ -263,6 +264,85 @@ def is_float_or_int(var):
     return is_int(var) or is_float(var)
 
 
+def is_datetime(var):
+    return isinstance(var, datetime)
+
+
+def is_date(var):
+    return isinstance(var, date) and not isinstance(var, datetime)
+
+
+TIMESTAMP_FORMATS = [
+    "%Y-%m-%dT%H:%M:%S.%f",
+    "%Y-%m-%dT%H:%M:%S",
+    "%Y-%m-%d %H:%M:%S.%f",
+    "%Y-%m-%d %H:%M:%S",
+    "%Y-%m-%d",
+]
+
+DATE_FORMAT = "%Y-%m-%d"
+
+
+def parse_timestamp(var):
+    """Parses an ISO 8601 string (UTC) or seconds since the Unix epoch into a naive UTC datetime"""
+    if is_datetime(var):
+        return var
+    if is_float_or_int(var):
+        return datetime.utcfromtimestamp(var)
+    if not is_str(var):
+        return None
+    if var.endswith("Z"):
+        var = var[:-1]
+    for timestamp_format in TIMESTAMP_FORMATS:
+        try:
+            return datetime.strptime(var, timestamp_format)
+        except ValueError:
+            pass
+    return None
+
+
+def parse_date(var):
+    """Parses a YYYY-MM-DD string into a date"""
+    if is_date(var):
+        return var
+    if not is_str(var):
+        return None
+    try:
+        return datetime.strptime(var, DATE_FORMAT).date()
+    except ValueError:
+        return None
+
+
+def is_timestamp_like(var):
+    return parse_timestamp(var) is not None
+
+
+def is_date_like(var):
+    return parse_date(var) is not None
+
+
+# Numbers are assumed to already be seconds since the Unix epoch (e.g. the feature baselines of a model)
+def timestamp_to_epoch_seconds(timestamp):
+    if is_float_or_int(timestamp):
+        return timestamp
+    return calendar.timegm(timestamp.utctimetuple())
+
+
+# Numbers are assumed to already be days since the Unix epoch (e.g. the feature baselines of a model)
+def date_to_epoch_days(d):
+    if is_float_or_int(d):
+        return d
+    return (d - date(1970, 1, 1)).days
+
+
+def isoformat_dates(var):
+    if is_datetime(var) or is_date(var):
+        return var.isoformat()
+    if is_list(var):
+        return [isoformat_dates(item) for item in var]
+    return var
+
+
 def is_int_list(var):
     if not is_list(var):
         return False
@@ -679,6 +759,9 @@ CORTEX_TYPE_TO_VALIDATOR = {
     consts.COLUMN_TYPE_FLOAT_LIST: is_float_list,
     consts.COLUMN_TYPE_STRING: is_str,
     consts.COLUMN_TYPE_STRING_LIST: is_str_list,
+    consts.COLUMN_TYPE_BOOL: is_bool,
+    consts.COLUMN_TYPE_TIMESTAMP: is_datetime,
+    consts.COLUMN_TYPE_DATE: is_date,
     consts.VALUE_TYPE_INT: is_int,
     consts.VALUE_TYPE_FLOAT: is_float,
     consts.VALUE_TYPE_STRING: is_str,
@@ -690,12 +773,16 @@ CORTEX_TYPE_TO_UPCAST_VALIDATOR = merge_dicts_overwrite(
     {
         consts.COLUMN_TYPE_FLOAT: is_float_or_int,
         consts.COLUMN_TYPE_FLOAT_LIST: is_float_or_int_list,
+        consts.COLUMN_TYPE_TIMESTAMP: is_timestamp_like,
+        consts.COLUMN_TYPE_DATE: is_date_like,
     },
 )
 
 CORTEX_TYPE_TO_UPCASTER = {
     consts.COLUMN_TYPE_FLOAT: lambda x: float(x),
     consts.COLUMN_TYPE_FLOAT_LIST: lambda ls: [float(item) for item in ls],
+    consts.COLUMN_TYPE_TIMESTAMP: parse_timestamp,
+    consts.COLUMN_TYPE_DATE: parse_date,
 }
 
 
@@ -92,20 +92,32 @@ def parse_args(args):
 
 def validate_dataset(ctx, raw_df, cols_to_validate):
     total_row_count = ctx.storage.get_json(ctx.raw_dataset["metadata_key"])["dataset_size"]
-    conditions_dict = spark_util.value_check_data(ctx, raw_df, cols_to_validate)
-
-    if len(conditions_dict) > 0:
-        for column, cond_count_list in conditions_dict.items():
-            for condition, fail_count in cond_count_list:
-                logger.error(
-                    "Data validation {} has been violated in {}/{} samples".format(
-                        condition, fail_count, total_row_count
-                    )
+    reports = spark_util.data_quality_reports(ctx, raw_df, cols_to_validate, total_row_count)
+
+    failed_columns = []
+    for raw_column_id, report in reports.items():
+        ctx.storage.put_json(report, ctx.quality_report_key(raw_column_id))
+
+        log = logger.info if report["passed"] else logger.error
+        for violation in report["violations"]:
+            log(
+                "Data validation {} has been violated in {}/{} samples".format(
+                    violation["description"], violation["num_rows"], total_row_count
                 )
-        raise UserException("raw column validations failed")
+            )
+        if not report["passed"]:
+            failed_columns.append(report["column_name"])
+
+    if len(failed_columns) > 0:
+        raise UserException(
+            "raw column validations failed for " + ", ".join(sorted(failed_columns)),
+            "run `cortex get raw_column NAME` to see the data quality report",
+        )
 
 
 def limit_dataset(full_dataset_size, ingest_df, limit_config):
+    if full_dataset_size == 0:
+        return ingest_df
     max_rows = full_dataset_size
     if limit_config.get("num_rows") is not None:
         max_rows = min(limit_config["num_rows"], full_dataset_size)
@@ -115,6 +127,28 @@ def limit_dataset(full_dataset_size, ingest_df, limit_config):
         max_rows = round(full_dataset_size * fraction)
     if max_rows == full_dataset_size:
         return ingest_df
+    if limit_config.get("stratify_by") is not None:
+        logger.info(
+            "Selecting a subset of data of at most {} rows, stratified by {}".format(
+                max_rows, limit_config["stratify_by"]
+            )
+        )
+        return spark_util.stratified_sample(
+            ingest_df,
+            limit_config["stratify_by"],
+            max_rows,
+            full_dataset_size,
+            limit_config.get("random_seed"),
+        )
+    if limit_config.get("sample_by_key") is not None:
+        logger.info(
+            "Selecting a subset of data of at most {} rows, sampled by {}".format(
+                max_rows, limit_config["sample_by_key"]
+            )
+        )
+        return spark_util.sample_by_key(
+            ingest_df, limit_config["sample_by_key"], max_rows, limit_config.get("random_seed")
+        )
     if limit_config["randomize"]:
         fraction = min(
             fraction * 1.1, 1.0  # increase the odds of getting the desired target row count
@@ -131,6 +165,16 @@ def write_raw_dataset(df, ctx, spark):
     return acc.value
 
 
+def profile_raw_dataset(ctx, raw_df, dataset_size):
+    """Profiles are informational, so ingestion doesn't fail if they can't be computed"""
+    logger.info("Profiling raw columns")
+    try:
+        profiles = spark_util.profile_columns(ctx, raw_df, dataset_size)
+        ctx.storage.put_json(profiles, ctx.raw_dataset["profiles_key"])
+    except Exception:
+        logger.exception("Unable to profile the raw columns")
+
+
 def ingest_raw_dataset(spark, ctx, cols_to_validate, should_ingest):
     if should_ingest:
         cols_to_validate = list(ctx.rf_id_map.keys())
@@ -147,25 +191,35 @@ def ingest_raw_dataset(spark, ctx, cols_to_validate, should_ingest):
             data_config = ctx.environment["data"]
 
             logger.info("Ingesting")
-            logger.info("Ingesting {} data from {}".format(ctx.app["name"], data_config["path"]))
+            logger.info(
+                "Ingesting {} data from {}".format(
+                    ctx.app["name"], spark_util.data_location(data_config)
+                )
+            )
             ingest_df = spark_util.ingest(ctx, spark)
 
-            full_dataset_size = ingest_df.count()
+            input_dataset_size = ingest_df.count()
 
-            if data_config.get("drop_null"):
-                logger.info("Dropping any rows that contain null values")
-                ingest_df = ingest_df.dropna()
+            ingest_df, fill_values = spark_util.clean_raw_dataset(ctx, ingest_df)
 
+            # the limit applies to the rows which remain after dropping and filtering
+            full_dataset_size = None
             if ctx.environment.get("limit"):
+                full_dataset_size = ingest_df.count()
                 ingest_df = limit_dataset(full_dataset_size, ingest_df, ctx.environment["limit"])
 
             written_count = write_raw_dataset(ingest_df, ctx, spark)
-            metadata = {"dataset_size": written_count}
+            if full_dataset_size is None:
+                full_dataset_size = written_count
+            metadata = {"dataset_size": written_count, "fill_values": fill_values}
             ctx.storage.put_json(metadata, ctx.raw_dataset["metadata_key"])
-            if written_count != full_dataset_size:
+            if written_count != input_dataset_size:
                 logger.info(
-                    "{} rows read, {} rows dropped, {} rows ingested".format(
-                        full_dataset_size, full_dataset_size - written_count, written_count
+                    "{} rows read, {} dropped, {} excluded by the limit, {} ingested".format(
+                        input_dataset_size,
+                        input_dataset_size - full_dataset_size,
+                        full_dataset_size - written_count,
+                        written_count,
                     )
                 )
             else:
@@ -173,6 +227,10 @@ def ingest_raw_dataset(spark, ctx, cols_to_validate, should_ingest):
 
         logger.info("Reading {} data (version: {})".format(ctx.app["name"], ctx.dataset_version))
         raw_df = spark_util.read_raw_dataset(ctx, spark)
+
+        if should_ingest:
+            profile_raw_dataset(ctx, raw_df, written_count)
+
         validate_dataset(ctx, raw_df, cols_to_validate)
     except:
         ctx.upload_resource_status_failed(*col_resources_to_validate)
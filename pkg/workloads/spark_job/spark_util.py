@@ -15,9 +15,11 @@
 from functools import reduce
 
 import os
+import math
 
 from pyspark.sql.types import *
 from pyspark.sql.dataframe import DataFrame
+from pyspark.sql.window import Window
 import pyspark.sql.functions as F
 
 from lib import util
@@ -35,6 +37,9 @@ CORTEX_TYPE_TO_SPARK_TYPE = {
     consts.COLUMN_TYPE_FLOAT_LIST: ArrayType(FloatType(), True),
     consts.COLUMN_TYPE_STRING: StringType(),
     consts.COLUMN_TYPE_STRING_LIST: ArrayType(StringType(), True),
+    consts.COLUMN_TYPE_BOOL: BooleanType(),
+    consts.COLUMN_TYPE_TIMESTAMP: TimestampType(),
+    consts.COLUMN_TYPE_DATE: DateType(),
 }
 
 FLOAT_PRECISION = 1e-4
@@ -46,6 +51,9 @@ CORTEX_TYPE_TO_ACCEPTABLE_SPARK_TYPES = {
     consts.COLUMN_TYPE_FLOAT_LIST: [ArrayType(FloatType(), True), ArrayType(DoubleType(), True)],
     consts.COLUMN_TYPE_STRING: [StringType()],
     consts.COLUMN_TYPE_STRING_LIST: [ArrayType(StringType(), True)],
+    consts.COLUMN_TYPE_BOOL: [BooleanType()],
+    consts.COLUMN_TYPE_TIMESTAMP: [TimestampType(), StringType()],
+    consts.COLUMN_TYPE_DATE: [DateType(), StringType()],
 }
 
 
@@ -73,12 +81,35 @@ def log_df_schema(df, logger_func=logger.info):
         logger_func(line)
 
 
+def to_tfrecord_types(df):
+    """Converts boolean, timestamp and date columns to longs, since tfrecords can't store them directly
+
+    Timestamps become seconds since the Unix epoch, and dates become days since the Unix epoch.
+    """
+    for field in df.schema.fields:
+        if isinstance(field.dataType, (BooleanType, TimestampType)):
+            df = df.withColumn(field.name, F.col(field.name).cast(LongType()))
+        elif isinstance(field.dataType, DateType):
+            epoch = F.lit("1970-01-01").cast(DateType())
+            df = df.withColumn(field.name, F.datediff(F.col(field.name), epoch).cast(LongType()))
+    return df
+
+
 def write_training_data(model_name, df, ctx, spark):
     model = ctx.models[model_name]
     training_dataset = model["dataset"]
-    column_names = model["feature_columns"] + [model["target_column"]] + model["training_columns"]
+    column_names = (
+        model["feature_columns"]
+        + ctx.get_target_column_names(model_name)
+        + ctx.get_training_column_names(model_name)
+    )
 
     df = df.select(*column_names)
+    weight_metadata = get_weight_metadata(model_name, df, ctx)
+    df = to_tfrecord_types(df)
+
+    if training_dataset["fold_keys"]:
+        return write_training_data_folds(training_dataset, df, ctx, spark, weight_metadata)
 
     train_ratio = model["data_partition_ratio"]["training"]
     eval_ratio = model["data_partition_ratio"]["evaluation"]
@@ -95,11 +126,50 @@ def write_training_data(model_name, df, ctx, spark):
     )
 
     metadata = {"training_size": train_df_acc.value, "eval_size": eval_df_acc.value}
+    metadata.update(weight_metadata)
+    ctx.storage.put_json(metadata, training_dataset["metadata_key"])
+
+    return df
+
+
+def write_training_data_folds(training_dataset, df, ctx, spark, weight_metadata):
+    fold_keys = training_dataset["fold_keys"]
+    fold_dfs = df.randomSplit([1.0] * len(fold_keys))
+
+    fold_sizes = []
+    for fold_df, fold_key in zip(fold_dfs, fold_keys):
+        fold_df_acc, fold_df = accumulate_count(fold_df, spark)
+        fold_df.write.mode("overwrite").format("tfrecords").option("recordType", "Example").save(
+            ctx.storage.hadoop_path(fold_key)
+        )
+        fold_sizes.append(fold_df_acc.value)
+
+    total_size = sum(fold_sizes)
+    metadata = {"training_size": total_size, "eval_size": total_size, "fold_sizes": fold_sizes}
+    metadata.update(weight_metadata)
     ctx.storage.put_json(metadata, training_dataset["metadata_key"])
 
     return df
 
 
+def get_weight_metadata(model_name, df, ctx):
+    """Computes the class weights of a model with class_weight: balanced"""
+    model = ctx.models[model_name]
+    if model["class_weight"] != consts.CLASS_WEIGHT_BALANCED:
+        return {}
+
+    # examples without a label aren't counted (and aren't weighted by class)
+    target_column = model["target_column"]
+    labeled_df = df.filter(F.col(target_column).isNotNull())
+    aggregator_impl, _ = ctx.get_aggregator_impl_by_name(consts.CLASS_WEIGHT_AGGREGATOR)
+    distribution = aggregator_impl.aggregate_spark(labeled_df, {"col": target_column}, {})
+    num_classes = len(distribution)
+    class_weights = {
+        str(label): 1.0 / (num_classes * frequency) for label, frequency in distribution.items()
+    }
+    return {"class_weights": class_weights}
+
+
 def min_check(input_col, min):
     return input_col >= min, input_col < min
 
@@ -119,6 +189,39 @@ def values_check(input_col, values):
     return input_col.isin(values), input_col.isin(values) == False
 
 
+def list_min_check(input_col, min):
+    return F.array_min(input_col) >= min, F.array_min(input_col) < min
+
+
+def list_max_check(input_col, max):
+    return F.array_max(input_col) <= max, F.array_max(input_col) > max
+
+
+def list_values_check(input_col, values, list_type):
+    # the size of a null list is -1, so null lists pass
+    values_col = F.array(*[F.lit(v) for v in values]).cast(list_type)
+    unexpected_values = F.array_except(input_col, values_col)
+    return F.size(unexpected_values) <= 0, F.size(unexpected_values) > 0
+
+
+def max_length_check(input_col, max_length):
+    return F.size(input_col) <= max_length, F.size(input_col) > max_length
+
+
+def pattern_check(input_col, pattern):
+    # the whole value must match the pattern
+    full_match = input_col.rlike("^(?:{})$".format(pattern))
+    return full_match, full_match == False
+
+
+def min_length_check(input_col, min_length):
+    return F.length(input_col) >= min_length, F.length(input_col) < min_length
+
+
+def string_max_length_check(input_col, max_length):
+    return F.length(input_col) <= max_length, F.length(input_col) > max_length
+
+
 def generate_conditions(condition_map, raw_column_config, input_col):
     for cond_name in condition_map.keys():
         if raw_column_config.get(cond_name) is not None:
@@ -134,12 +237,30 @@ def generate_conditions(condition_map, raw_column_config, input_col):
 
 
 def value_checker(raw_column_config):
-    condition_map = {
-        "min": min_check,
-        "max": max_check,
-        "required": required_check,
-        "values": values_check,
-    }
+    if raw_column_config["type"] in consts.COLUMN_LIST_TYPES:
+        list_type = CORTEX_TYPE_TO_SPARK_TYPE[raw_column_config["type"]]
+        condition_map = {
+            "min": list_min_check,
+            "max": list_max_check,
+            "required": required_check,
+            "values": lambda col, values: list_values_check(col, values, list_type),
+            "max_length": max_length_check,
+        }
+    elif raw_column_config["type"] == consts.COLUMN_TYPE_STRING:
+        condition_map = {
+            "required": required_check,
+            "values": values_check,
+            "pattern": pattern_check,
+            "min_length": min_length_check,
+            "max_length": string_max_length_check,
+        }
+    else:
+        condition_map = {
+            "min": min_check,
+            "max": max_check,
+            "required": required_check,
+            "values": values_check,
+        }
 
     input_col = F.col(raw_column_config["name"])
 
@@ -186,11 +307,241 @@ def value_check_data(ctx, df, raw_columns=None):
     return conditions_dict
 
 
+def sample_rows(df, n):
+    return [
+        {name: util.isoformat_dates(value) for name, value in row.asDict().items()}
+        for row in df.limit(n).collect()
+    ]
+
+
+def quality_violation(constraint, description, num_rows, total_row_count, samples):
+    return {
+        "constraint": constraint,
+        "description": description,
+        "num_rows": num_rows,
+        "fraction": float(num_rows) / total_row_count if total_row_count > 0 else 0.0,
+        "sample_rows": samples,
+    }
+
+
+def data_quality_reports(ctx, df, raw_columns=None, total_row_count=None, num_sample_rows=5):
+    """Checks every constraint of the raw columns, and reports the violations of each column (by ID)
+
+    A column passes if no constraint is violated by more than error_threshold of the rows, and its
+    fraction of null values is at most max_null_fraction.
+    """
+    if raw_columns is None:
+        raw_columns = list(ctx.rf_id_map.keys())
+    if total_row_count is None:
+        total_row_count = df.count()
+
+    column_value_checkers_list = [
+        cvc for f in raw_columns for cvc in value_checker(ctx.rf_id_map[f])
+    ]
+    cvc_dict = {
+        ("{}_{}".format(d["col_name"], d["cond_name"])): d for d in column_value_checkers_list
+    }
+
+    # count the violations of every constraint in a single pass over the data
+    # (sums are null if there are no rows)
+    aggregate_checkers = [
+        F.coalesce(F.sum(F.when(d["cond_col"], 1).otherwise(0)), F.lit(0)).alias(cond_col_name)
+        for cond_col_name, d in cvc_dict.items()
+    ]
+    for f in raw_columns:
+        name = ctx.rf_id_map[f]["name"]
+        if ctx.rf_id_map[f].get("max_null_fraction") is not None:
+            null_count = F.sum(F.when(F.col(name).isNull(), 1).otherwise(0))
+            aggregate_checkers.append(F.coalesce(null_count, F.lit(0)).alias(name + "_null_count"))
+        if ctx.rf_id_map[f].get("unique"):
+            aggregate_checkers.append(
+                (F.count(name) - F.countDistinct(name)).alias(name + "_duplicate_count")
+            )
+
+    results_dict = {}
+    if len(aggregate_checkers) > 0:
+        results_dict = df.agg(*aggregate_checkers).collect()[0].asDict()
+
+    reports = {}
+    for f in raw_columns:
+        raw_column = ctx.rf_id_map[f]
+        name = raw_column["name"]
+        error_threshold = raw_column.get("error_threshold") or 0
+        passed = True
+        violations = []
+
+        for cond_col_name, d in cvc_dict.items():
+            count = results_dict[cond_col_name]
+            if d["col_name"] != name or count == 0:
+                continue
+            violation = quality_violation(
+                d["cond_name"],
+                d["positive_cond_str"],
+                count,
+                total_row_count,
+                sample_rows(df.filter(d["cond_col"]), num_sample_rows),
+            )
+            violations.append(violation)
+            if violation["fraction"] > error_threshold:
+                passed = False
+
+        if raw_column.get("max_null_fraction") is not None:
+            null_count = results_dict[name + "_null_count"]
+            if null_count > 0:
+                violation = quality_violation(
+                    "max_null_fraction",
+                    "(fraction of null values <= {})".format(raw_column["max_null_fraction"]),
+                    null_count,
+                    total_row_count,
+                    [],
+                )
+                if violation["fraction"] > raw_column["max_null_fraction"]:
+                    violations.append(violation)
+                    passed = False
+
+        if raw_column.get("unique"):
+            duplicate_count = results_dict[name + "_duplicate_count"]
+            if duplicate_count > 0:
+                duplicates_df = (
+                    df.groupBy(name)
+                    .agg(F.count(F.lit(1)).alias("num_occurrences"))
+                    .filter(F.col("num_occurrences") > 1)
+                    .orderBy(F.desc("num_occurrences"))
+                )
+                violation = quality_violation(
+                    "unique",
+                    "({} values are unique)".format(name),
+                    duplicate_count,
+                    total_row_count,
+                    sample_rows(duplicates_df, num_sample_rows),
+                )
+                violations.append(violation)
+                if violation["fraction"] > error_threshold:
+                    passed = False
+
+        reports[f] = {
+            "column_name": name,
+            "num_rows": total_row_count,
+            "error_threshold": error_threshold,
+            "passed": passed,
+            "violations": violations,
+        }
+
+    return reports
+
+
+def finite_float(value):
+    if value is None or math.isnan(value) or math.isinf(value):
+        return None
+    return float(value)
+
+
+def finite_value_col(column_name, column_type):
+    """The column's values, with NaN and infinite FLOAT_COLUMN values replaced by null"""
+    col = F.col(column_name)
+    if column_type != consts.COLUMN_TYPE_FLOAT:
+        return col
+    return F.when(~F.isnan(col) & (F.abs(col) != float("inf")), col)
+
+
+def histogram(df, value_col, min_value, max_value, num_bins):
+    """Counts the non-null values in equal-width bins between min_value and max_value (the last bin
+    includes max_value), a single bin is used if every value is the same"""
+    width = float(max_value - min_value) / num_bins
+    if width == 0 or math.isinf(width):
+        num_bins = 1
+        width = float(max_value - min_value)
+        bin_col = F.lit(0)
+    else:
+        bin_col = F.least(F.floor((value_col - min_value) / width), F.lit(num_bins - 1))
+
+    rows = (
+        df.filter(value_col.isNotNull())
+        .select(bin_col.cast(IntegerType()).alias("bin"))
+        .groupBy("bin")
+        .count()
+        .collect()
+    )
+    counts = {row["bin"]: row["count"] for row in rows}
+
+    bins = []
+    for i in range(num_bins):
+        end = float(max_value) if i == num_bins - 1 else min_value + (i + 1) * width
+        bins.append({"start": min_value + i * width, "end": end, "count": counts.get(i, 0)})
+    return bins
+
+
+def top_values(df, column_name, num_values):
+    rows = (
+        df.filter(F.col(column_name).isNotNull())
+        .groupBy(column_name)
+        .agg(F.count(F.lit(1)).alias("num_occurrences"))
+        .orderBy(F.desc("num_occurrences"))
+        .limit(num_values)
+        .collect()
+    )
+    return [{"value": row[0], "count": row[1]} for row in rows]
+
+
+def profile_columns(ctx, df, total_row_count=None, num_top_values=10, num_histogram_bins=10):
+    """Summarizes the values of every raw column (by name), distinct counts are approximate"""
+    if total_row_count is None:
+        total_row_count = df.count()
+
+    numeric_types = [consts.COLUMN_TYPE_INT, consts.COLUMN_TYPE_FLOAT]
+
+    # NaN and infinite values are excluded from the statistics and histograms of numeric columns
+    aggregates = []
+    for name, raw_column in ctx.raw_columns.items():
+        aggregates.append(F.count(name).alias(name + "_count"))
+        if raw_column["type"] not in consts.COLUMN_LIST_TYPES:
+            aggregates.append(F.approx_count_distinct(name).alias(name + "_distinct_count"))
+        if raw_column["type"] in numeric_types:
+            value_col = finite_value_col(name, raw_column["type"])
+            aggregates.append(F.min(value_col).alias(name + "_min"))
+            aggregates.append(F.max(value_col).alias(name + "_max"))
+            aggregates.append(F.mean(value_col).alias(name + "_mean"))
+            aggregates.append(F.stddev(value_col).alias(name + "_stddev"))
+
+    results_dict = {}
+    if len(aggregates) > 0:
+        results_dict = df.agg(*aggregates).collect()[0].asDict()
+
+    profiles = {}
+    for name, raw_column in ctx.raw_columns.items():
+        non_null_count = results_dict[name + "_count"]
+        profile = {
+            "count": total_row_count,
+            "null_count": total_row_count - non_null_count,
+            "distinct_count": results_dict.get(name + "_distinct_count"),
+        }
+
+        if raw_column["type"] in numeric_types:
+            for stat in ["min", "max", "mean", "stddev"]:
+                profile[stat] = finite_float(results_dict[name + "_" + stat])
+            if profile["min"] is not None and profile["max"] is not None:
+                value_col = finite_value_col(name, raw_column["type"])
+                profile["histogram"] = histogram(
+                    df, value_col, profile["min"], profile["max"], num_histogram_bins
+                )
+
+        if raw_column["type"] in [consts.COLUMN_TYPE_STRING, consts.COLUMN_TYPE_BOOL]:
+            profile["top_values"] = top_values(df, name, num_top_values)
+
+        profiles[name] = profile
+
+    return profiles
+
+
+def nullable_elements(data_type):
+    """Parquet arrays may declare their elements as non-nullable, which doesn't affect ingestion"""
+    if isinstance(data_type, ArrayType):
+        return ArrayType(data_type.elementType, True)
+    return data_type
+
+
 def ingest(ctx, spark):
-    if ctx.environment["data"]["type"] == "csv":
-        df = read_csv(ctx, spark)
-    elif ctx.environment["data"]["type"] == "parquet":
-        df = read_parquet(ctx, spark)
+    df = read_data(ctx, spark, ctx.environment["data"])
 
     input_type_map = {f.name: f.dataType for f in df.schema}
 
@@ -198,7 +549,7 @@ def ingest(ctx, spark):
         raw_column = ctx.raw_columns[raw_column_name]
         expected_types = CORTEX_TYPE_TO_ACCEPTABLE_SPARK_TYPES[raw_column["type"]]
         actual_type = input_type_map[raw_column_name]
-        if actual_type not in expected_types:
+        if nullable_elements(actual_type) not in expected_types:
             logger.error("found schema:")
             log_df_schema(df, logger.error)
 
@@ -217,8 +568,33 @@ def ingest(ctx, spark):
     return df.select(*sorted(df.columns))
 
 
-def read_csv(ctx, spark):
-    data_config = ctx.environment["data"]
+def read_data(ctx, spark, data_config, env_suffix=""):
+    if data_config["type"] == "csv":
+        return read_csv(ctx, spark, data_config)
+    elif data_config["type"] == "parquet":
+        return read_parquet(ctx, spark, data_config)
+    elif data_config["type"] == "json":
+        return read_json(ctx, spark, data_config)
+    elif data_config["type"] == "avro":
+        return read_avro(ctx, spark, data_config)
+    elif data_config["type"] == "jdbc":
+        return read_jdbc(ctx, spark, data_config, env_suffix)
+    elif data_config["type"] == "join":
+        return read_join(ctx, spark, data_config)
+
+
+def data_location(data_config):
+    if data_config["type"] == "jdbc":
+        return data_config["url"]
+    if data_config["type"] == "join":
+        return ", ".join(
+            "{} ({})".format(source["name"], data_location(source["data"]))
+            for source in data_config["sources"]
+        )
+    return data_config["path"]
+
+
+def read_csv(ctx, spark, data_config):
     expected_field_names = data_config["schema"]
 
     schema_fields = []
@@ -239,11 +615,10 @@ def read_csv(ctx, spark):
     df = spark.read.csv(
         data_config["path"], schema=StructType(schema_fields), mode="FAILFAST", **csv_config
     )
-    return df.select(*ctx.raw_columns.keys())
+    return df.select(*[c for c in ctx.raw_columns.keys() if c in expected_field_names])
 
 
-def read_parquet(ctx, spark):
-    parquet_config = ctx.environment["data"]
+def read_parquet(ctx, spark, parquet_config):
     df = spark.read.parquet(parquet_config["path"])
 
     alias_map = {
@@ -263,6 +638,249 @@ def read_parquet(ctx, spark):
     return df.selectExpr(*selectExprs)
 
 
+def read_json(ctx, spark, json_config):
+    df = spark.read.json(json_config["path"], mode="FAILFAST")
+    return select_field_paths(ctx, df, json_config["schema"], "json_field_path")
+
+
+def read_avro(ctx, spark, avro_config):
+    df = spark.read.format("avro").load(avro_config["path"])
+    return select_field_paths(ctx, df, avro_config["schema"], "avro_field_path")
+
+
+def read_jdbc(ctx, spark, jdbc_config, env_suffix=""):
+
+    if jdbc_config.get("query") is not None:
+        table = "({}) AS cortex_query".format(jdbc_config["query"])
+    else:
+        table = jdbc_config["table"]
+
+    properties = {}
+    if os.environ.get("CORTEX_JDBC_USERNAME" + env_suffix) is not None:
+        properties["user"] = os.environ["CORTEX_JDBC_USERNAME" + env_suffix]
+    if os.environ.get("CORTEX_JDBC_PASSWORD" + env_suffix) is not None:
+        properties["password"] = os.environ["CORTEX_JDBC_PASSWORD" + env_suffix]
+
+    try:
+        df = spark.read.jdbc(
+            jdbc_config["url"],
+            table,
+            column=jdbc_config.get("partition_column"),
+            lowerBound=jdbc_config.get("lower_bound"),
+            upperBound=jdbc_config.get("upper_bound"),
+            numPartitions=jdbc_config.get("num_partitions"),
+            properties=properties,
+        )
+    except Exception as e:
+        raise UserException("unable to read from " + jdbc_config["url"]) from e
+
+    alias_map = {
+        c["raw_column_name"]: c["jdbc_column_name"]
+        for c in jdbc_config["schema"]
+        if c["raw_column_name"] in ctx.raw_columns
+    }
+
+    missing_cols = set(alias_map.values()) - set(df.columns)
+    if len(missing_cols) > 0:
+        logger.error("found schema:")
+        log_df_schema(df, logger.error)
+        raise UserException("missing column(s) in input dataset", str(missing_cols))
+
+    return df.select(*[F.col(col).alias(alias) for alias, col in alias_map.items()])
+
+
+def read_join(ctx, spark, join_config):
+    source_dfs = {}
+    for i, source in enumerate(join_config["sources"]):
+        source_df = read_data(ctx, spark, source["data"], env_suffix="_" + str(i))
+        if source["data"].get("drop_null"):
+            source_df = source_df.dropna()
+        source_dfs[source["name"]] = source_df
+
+    df = source_dfs[join_config["sources"][0]["name"]]
+    for join in join_config["joins"]:
+        df = df.join(source_dfs[join["source"]], on=join["keys"], how=join["type"])
+    return df
+
+
+def select_field_paths(ctx, df, schema, field_path_key):
+    path_map = {
+        c["raw_column_name"]: c[field_path_key]
+        for c in schema
+        if c["raw_column_name"] in ctx.raw_columns
+    }
+
+    missing_paths = [path for path in path_map.values() if not has_field_path(df.schema, path)]
+    if len(missing_paths) > 0:
+        logger.error("found schema:")
+        log_df_schema(df, logger.error)
+        raise UserException("missing column(s) in input dataset", str(sorted(missing_paths)))
+
+    return df.select(
+        *[F.col(path).alias(raw_column_name) for raw_column_name, path in path_map.items()]
+    )
+
+
+def has_field_path(struct_type, field_path):
+    data_type = struct_type
+    for field_name in field_path.split("."):
+        if not isinstance(data_type, StructType) or field_name not in data_type.names:
+            return False
+        data_type = data_type[field_name].dataType
+    return True
+
+
+FILTER_OPS = {
+    "eq": lambda col, value: col == value,
+    "ne": lambda col, value: col != value,
+    "lt": lambda col, value: col < value,
+    "lte": lambda col, value: col <= value,
+    "gt": lambda col, value: col > value,
+    "gte": lambda col, value: col >= value,
+    "in": lambda col, values: col.isin(values),
+    "not_in": lambda col, values: ~col.isin(values),
+    "date_range": lambda col, dates: F.to_date(col).between(
+        F.to_date(F.lit(dates[0])), F.to_date(F.lit(dates[1]))
+    ),
+}
+
+
+def filter_rows(df, filter_conditions):
+    """Keep the rows which satisfy every condition (rows with a null in a filtered column are dropped)"""
+    conditions = [
+        FILTER_OPS[condition["op"]](F.col(condition["column"]), condition["value"])
+        for condition in filter_conditions
+    ]
+    return df.filter(reduce(lambda a, b: a & b, conditions))
+
+
+def stratified_sample(df, column, max_rows, total_rows, seed=None):
+    """Select floor(count * max_rows / total_rows) rows from each value of column, so that each value
+    keeps its proportion of the rows and at most max_rows rows are selected"""
+    value_window = Window.partitionBy(column)
+    df = df.withColumn("_cortex_rank", F.row_number().over(value_window.orderBy(F.rand(seed))))
+    df = df.withColumn("_cortex_count", F.count(F.lit(1)).over(value_window))
+    num_rows_to_keep = F.floor(F.col("_cortex_count") * max_rows / total_rows)
+    df = df.filter(F.col("_cortex_rank") <= num_rows_to_keep)
+    return df.drop("_cortex_rank", "_cortex_count")
+
+
+def sample_by_key(df, column, max_rows, seed=None):
+    """Select all of the rows of randomly ordered values of column while the total is at most max_rows
+    (the rows of each value are either all kept or all dropped)"""
+    if seed is None:
+        seed = 0
+    key_counts = df.groupBy(column).agg(F.count(F.lit(1)).alias("_cortex_count"))
+    key_order = Window.orderBy(F.hash(F.col(column), F.lit(seed)), column).rowsBetween(
+        Window.unboundedPreceding, Window.currentRow
+    )
+    keys = (
+        key_counts.withColumn("_cortex_total", F.sum("_cortex_count").over(key_order))
+        .filter(F.col("_cortex_total") <= max_rows)
+        .select(F.col(column).alias("_cortex_key"))
+    )
+    df = df.join(keys, df[column].eqNullSafe(keys["_cortex_key"]))
+    return df.drop("_cortex_key")
+
+
+def compute_fill_value(df, raw_column):
+    column_name = raw_column["name"]
+    fill_aggregate = raw_column.get("fill_aggregate")
+    if fill_aggregate is None:
+        return raw_column["fill_value"]
+
+    if fill_aggregate == "mean":
+        value = df.agg(F.mean(column_name)).collect()[0][0]
+    elif fill_aggregate == "median":
+        quantiles = df.approxQuantile(column_name, [0.5], 0.001)
+        value = quantiles[0] if len(quantiles) > 0 else None
+    elif fill_aggregate == "mode":
+        mode_row = (
+            df.filter(F.col(column_name).isNotNull())
+            .groupBy(column_name)
+            .count()
+            .orderBy(F.desc("count"), column_name)
+            .first()
+        )
+        value = mode_row[0] if mode_row is not None else None
+
+    if value is None:
+        raise UserException(
+            "raw column " + column_name,
+            "fill_aggregate",
+            "unable to compute the {} because all values are null".format(fill_aggregate),
+        )
+
+    if raw_column["type"] == consts.COLUMN_TYPE_INT:
+        return int(round(value))
+    return util.isoformat_dates(value)
+
+
+def fill_value_col(value, column_type):
+    spark_type = CORTEX_TYPE_TO_SPARK_TYPE[column_type]
+    if util.is_list(value):
+        return F.array(*[F.lit(item) for item in value]).cast(spark_type)
+    return F.lit(value).cast(spark_type)
+
+
+def clean_raw_dataset(ctx, df):
+    """Drops and filters rows, then applies each raw column's on_null action, returning the dataframe and
+    the fill values (on_null: fail and fill aggregates only consider the rows which are ingested)"""
+    if ctx.environment["data"].get("drop_null"):
+        # columns with an on_null action handle their own null values
+        drop_null_columns = [
+            column_name
+            for column_name in df.columns
+            if column_name not in ctx.raw_columns
+            or ctx.raw_columns[column_name].get("on_null") is None
+        ]
+        logger.info("Dropping any rows that contain null values")
+        df = df.dropna(subset=drop_null_columns)
+
+    if ctx.environment.get("filter"):
+        logger.info("Filtering rows")
+        df = filter_rows(df, ctx.environment["filter"])
+
+    return handle_nulls(ctx, df)
+
+
+def handle_nulls(ctx, df):
+    """Apply each raw column's on_null action, returning the dataframe and the fill values that were used"""
+    raw_columns = [c for c in ctx.raw_columns.values() if c.get("on_null") is not None]
+    fail_columns = [c["name"] for c in raw_columns if c["on_null"] == "fail"]
+    drop_columns = [c["name"] for c in raw_columns if c["on_null"] == "drop"]
+    fill_columns = [c for c in raw_columns if c["on_null"] == "fill"]
+
+    if len(fail_columns) > 0:
+        null_counts = df.agg(
+            *[F.sum(F.col(c).isNull().cast(LongType())).alias(c) for c in fail_columns]
+        ).collect()[0]
+        for column_name in fail_columns:
+            if null_counts[column_name]:
+                raise UserException(
+                    "raw column " + column_name,
+                    "on_null: fail",
+                    "found {} null values".format(null_counts[column_name]),
+                )
+
+    if len(drop_columns) > 0:
+        logger.info("Dropping rows with null values in " + ", ".join(drop_columns))
+        df = df.dropna(subset=drop_columns)
+
+    fill_values = {}
+    for raw_column in fill_columns:
+        fill_values[raw_column["name"]] = compute_fill_value(df, raw_column)
+
+    for column_name, value in fill_values.items():
+        logger.info("Filling null values in {} with {}".format(column_name, value))
+        column_type = ctx.raw_columns[column_name]["type"]
+        df = df.withColumn(
+            column_name, F.coalesce(F.col(column_name), fill_value_col(value, column_type))
+        )
+
+    return df, fill_values
+
+
 def column_names_to_index(columns_input_config):
     column_list = []
     for k, v in columns_input_config.items():
@@ -597,7 +1215,11 @@ def transform_column(column_name, df, ctx, spark):
 
 def transform(model_name, accumulated_df, ctx, spark):
     model = ctx.models[model_name]
-    column_names = model["feature_columns"] + [model["target_column"]] + model["training_columns"]
+    column_names = (
+        model["feature_columns"]
+        + ctx.get_target_column_names(model_name)
+        + ctx.get_training_column_names(model_name)
+    )
 
     for column_name in column_names:
         accumulated_df = transform_column(column_name, accumulated_df, ctx, spark)
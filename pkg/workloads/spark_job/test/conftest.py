@@ -96,6 +96,21 @@ def write_csv_file(request):
     return _write_csv_file
 
 
+@pytest.fixture(scope="function")
+def write_json_file(request):
+    def _write_json_file(json_string, path="."):
+        filename = str(uuid.uuid4()) + ".json"
+        path_to_file = os.path.join(path, filename)
+        with open(path_to_file, "w") as f:
+            f.write(json_string)
+
+        request.addfinalizer(lambda: os.remove(path_to_file))
+
+        return path_to_file
+
+    return _write_json_file
+
+
 @pytest.fixture(scope="function")
 def write_parquet_file(request):
     def _write_parquet_file(spark, tuple_list, schema, path="."):
@@ -41,6 +41,8 @@ raw_ctx = {
     "raw_dataset": {
         "key": "apps/iris/data/2019-03-08-09-58-35-701834/3976c5679bcf7cb550453802f4c3a9333c5f193f6097f1f5642de48d2397554/data_raw/raw.parquet",
         "metadata_key": "apps/iris/data/2019-03-08-09-58-35-701834/3976c5679bcf7cb550453802f4c3a9333c5f193f6097f1f5642de48d2397554/data_raw/metadata.json",
+        "quality_reports_prefix": "apps/iris/data/2019-03-08-09-58-35-701834/3976c5679bcf7cb550453802f4c3a9333c5f193f6097f1f5642de48d2397554/data_raw/quality_reports",
+        "profiles_key": "apps/iris/data/2019-03-08-09-58-35-701834/3976c5679bcf7cb550453802f4c3a9333c5f193f6097f1f5642de48d2397554/data_raw/profiles.json",
     },
     "aggregates": {
         "class_index": {
@@ -298,6 +300,12 @@ raw_ctx = {
             }
         },
         "raw_int_columns": {},
+        "raw_bool_columns": {},
+        "raw_timestamp_columns": {},
+        "raw_date_columns": {},
+        "raw_int_list_columns": {},
+        "raw_float_list_columns": {},
+        "raw_string_list_columns": {},
     },
     "environment_data": {
         "csv_data": {
@@ -450,6 +458,8 @@ raw_ctx = {
             "aggregates": ["class_index"],
             "impl_id": "2d7091a3fff24213d9e67cf2a846e5e31fd27f406fffbdb341140419f138f48",
             "training_columns": [],
+            "weight_column": "",
+            "class_weight": None,
             "key": "apps/iris/data/2019-03-08-09-58-35-701834/3976c5679bcf7cb550453802f4c3a9333c5f193f6097f1f5642de48d2397554/models/4989cb227eb56c2d3ccc1904cb3dbcab9a1ceb1ebf8cdb9f95a20b86a8df019.zip",
             "embed": None,
             "type": "classification",
@@ -0,0 +1,76 @@
+# Copyright 2019 Cortex Labs, Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+from spark_job import spark_job
+
+import pytest
+from pyspark.sql import Row
+
+
+pytestmark = pytest.mark.usefixtures("spark")
+
+
+def test_limit_dataset_num_rows(spark):
+    df = spark.createDataFrame([Row(id=i) for i in range(100)])
+
+    limit_config = {"num_rows": 30, "randomize": False, "random_seed": None}
+    assert spark_job.limit_dataset(100, df, limit_config).count() == 30
+
+    limit_config = {"num_rows": 200, "randomize": False, "random_seed": None}
+    assert spark_job.limit_dataset(100, df, limit_config).count() == 100
+
+    empty_df = spark.createDataFrame([], df.schema)
+    assert spark_job.limit_dataset(0, empty_df, limit_config).count() == 0
+
+
+def label_counts(df):
+    return {row["label"]: row["count"] for row in df.groupBy("label").count().collect()}
+
+
+def test_limit_dataset_stratify_by(spark):
+    data = [Row(label="a", id=i) for i in range(800)] + [Row(label="b", id=i) for i in range(200)]
+    df = spark.createDataFrame(data)
+
+    for seed in range(3):
+        limit_config = {
+            "num_rows": 301,
+            "stratify_by": "label",
+            "randomize": False,
+            "random_seed": seed,
+        }
+        limited_df = spark_job.limit_dataset(1000, df, limit_config)
+        assert label_counts(limited_df) == {"a": 240, "b": 60}
+
+    limit_config = {"fraction_of_rows": 0.25, "stratify_by": "label", "randomize": False}
+    limited_df = spark_job.limit_dataset(1000, df, limit_config)
+    assert label_counts(limited_df) == {"a": 200, "b": 50}
+
+
+def test_limit_dataset_sample_by_key(spark):
+    data = [Row(user=u, event=e) for u in range(200) for e in range(u % 5 + 1)]
+    df = spark.createDataFrame(data)
+    events_per_user = {row["user"]: row["count"] for row in df.groupBy("user").count().collect()}
+
+    for seed in range(3):
+        limit_config = {
+            "num_rows": 150,
+            "sample_by_key": "user",
+            "randomize": False,
+            "random_seed": seed,
+        }
+        limited_df = spark_job.limit_dataset(600, df, limit_config)
+        assert 0 < limited_df.count() <= 150
+
+        # every selected user keeps all of their events
+        for row in limited_df.groupBy("user").count().collect():
+            assert row["count"] == events_per_user[row["user"]]
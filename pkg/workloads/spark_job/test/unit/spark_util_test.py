@@ -12,6 +12,7 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 import math
+from datetime import datetime, date
 
 import spark_util
 from lib.exceptions import UserException
@@ -42,7 +43,10 @@ def test_read_csv_valid(spark, write_csv_file, ctx_obj, get_context):
         "c_long": {"name": "c_long", "type": "INT_COLUMN", "required": False, "id": "-"},
     }
 
-    assert spark_util.read_csv(get_context(ctx_obj), spark).count() == 3
+    assert (
+        spark_util.read_csv(get_context(ctx_obj), spark, ctx_obj["environment"]["data"]).count()
+        == 3
+    )
 
     ctx_obj["environment"] = {
         "data": {
@@ -52,7 +56,10 @@ def test_read_csv_valid(spark, write_csv_file, ctx_obj, get_context):
         }
     }
 
-    assert spark_util.read_csv(get_context(ctx_obj), spark).count() == 3
+    assert (
+        spark_util.read_csv(get_context(ctx_obj), spark, ctx_obj["environment"]["data"]).count()
+        == 3
+    )
 
 
 def test_read_csv_invalid_type(spark, write_csv_file, ctx_obj, get_context):
@@ -128,7 +135,7 @@ def test_read_csv_valid_options(spark, write_csv_file, ctx_obj, get_context):
         "c_long": {"name": "c_long", "type": "INT_COLUMN", "required": False, "id": "-"},
     }
 
-    result_df = spark_util.read_csv(get_context(ctx_obj), spark)
+    result_df = spark_util.read_csv(get_context(ctx_obj), spark, ctx_obj["environment"]["data"])
     actual_results = result_df.select(*sorted(result_df.columns)).collect()
 
     assert len(actual_results) == 3
@@ -143,6 +150,68 @@ def test_read_csv_valid_options(spark, write_csv_file, ctx_obj, get_context):
     )
 
 
+def test_read_csv_bool_timestamp_date(spark, write_csv_file, ctx_obj, get_context):
+    csv_str = "\n".join(["true,01/03/2019 12:30,01/03/2019", "false,02/03/2019 00:00,02/03/2019"])
+    path_to_file = write_csv_file(csv_str)
+
+    ctx_obj["environment"] = {
+        "data": {
+            "type": "csv",
+            "path": path_to_file,
+            "schema": ["a_bool", "b_timestamp", "c_date"],
+            "csv_config": {"timestamp_format": "dd/MM/yyyy HH:mm", "date_format": "dd/MM/yyyy"},
+        }
+    }
+
+    ctx_obj["raw_columns"] = {
+        "a_bool": {"name": "a_bool", "type": "BOOL_COLUMN", "required": True, "id": "-"},
+        "b_timestamp": {
+            "name": "b_timestamp",
+            "type": "TIMESTAMP_COLUMN",
+            "required": True,
+            "id": "-",
+        },
+        "c_date": {"name": "c_date", "type": "DATE_COLUMN", "required": True, "id": "-"},
+    }
+
+    df = spark_util.ingest(get_context(ctx_obj), spark)
+    assert df.schema == StructType(
+        [
+            StructField("a_bool", BooleanType()),
+            StructField("b_timestamp", TimestampType()),
+            StructField("c_date", DateType()),
+        ]
+    )
+
+    actual_results = df.collect()
+    assert actual_results[0].a_bool == True
+    assert actual_results[0].b_timestamp == datetime(2019, 3, 1, 12, 30)
+    assert actual_results[1].c_date == date(2019, 3, 2)
+
+
+def test_to_tfrecord_types(spark):
+    df = spark.createDataFrame(
+        [(True, date(1970, 1, 11), "a")],
+        StructType(
+            [
+                StructField("a_bool", BooleanType()),
+                StructField("b_date", DateType()),
+                StructField("c_str", StringType()),
+            ]
+        ),
+    )
+
+    result_df = spark_util.to_tfrecord_types(df)
+    assert result_df.schema == StructType(
+        [
+            StructField("a_bool", LongType()),
+            StructField("b_date", LongType()),
+            StructField("c_str", StringType()),
+        ]
+    )
+    assert result_df.collect()[0] == Row(a_bool=1, b_date=10, c_str="a")
+
+
 def test_value_checker_required():
     raw_column_config = {"name": "a_str", "type": "STRING_COLUMN", "required": True}
     results = list(spark_util.value_checker(raw_column_config))
@@ -348,6 +417,92 @@ def test_ingest_parquet_valid(spark, write_parquet_file, ctx_obj, get_context):
     ]
 
 
+def test_ingest_parquet_list_columns(spark, write_parquet_file, ctx_obj, get_context):
+    data = [([1, 2], [0.5], ["a", "b"]), (None, [], ["c"])]
+
+    schema = StructType(
+        [
+            StructField("a_long_list", ArrayType(IntegerType(), False)),
+            StructField("b_float_list", ArrayType(DoubleType(), True)),
+            StructField("c_str_list", ArrayType(StringType(), True)),
+        ]
+    )
+
+    path_to_file = write_parquet_file(spark, data, schema)
+
+    ctx_obj["environment"] = {
+        "data": {
+            "type": "parquet",
+            "path": path_to_file,
+            "schema": [
+                {"parquet_column_name": "a_long_list", "raw_column_name": "a_long_list"},
+                {"parquet_column_name": "b_float_list", "raw_column_name": "b_float_list"},
+                {"parquet_column_name": "c_str_list", "raw_column_name": "tags"},
+            ],
+        }
+    }
+
+    ctx_obj["raw_columns"] = {
+        "a_long_list": {"name": "a_long_list", "type": "INT_LIST_COLUMN", "id": "1"},
+        "b_float_list": {"name": "b_float_list", "type": "FLOAT_LIST_COLUMN", "id": "2"},
+        "tags": {"name": "tags", "type": "STRING_LIST_COLUMN", "id": "3"},
+    }
+
+    df = spark_util.ingest(get_context(ctx_obj), spark)
+
+    assert df.count() == 2
+    assert [(s.name, s.dataType) for s in df.schema] == [
+        ("a_long_list", ArrayType(LongType(), True)),
+        ("b_float_list", ArrayType(FloatType(), True)),
+        ("tags", ArrayType(StringType(), True)),
+    ]
+
+
+def test_value_check_data_list_columns(spark, ctx_obj, get_context):
+    data = [([0, 1], [0.5], ["a"]), ([1, 5], [1.5, 0.5, 0.5], ["a", "z"]), (None, None, None)]
+
+    schema = StructType(
+        [
+            StructField("a_long_list", ArrayType(LongType(), True)),
+            StructField("b_float_list", ArrayType(FloatType(), True)),
+            StructField("c_str_list", ArrayType(StringType(), True)),
+        ]
+    )
+
+    df = spark.createDataFrame(data, schema)
+
+    ctx_obj["raw_columns"] = {
+        "a_long_list": {
+            "name": "a_long_list",
+            "type": "INT_LIST_COLUMN",
+            "min": 0,
+            "max": 2,
+            "id": 1,
+        },
+        "b_float_list": {
+            "name": "b_float_list",
+            "type": "FLOAT_LIST_COLUMN",
+            "values": [0.5, 1.5],
+            "max_length": 2,
+            "id": 2,
+        },
+        "c_str_list": {
+            "name": "c_str_list",
+            "type": "STRING_LIST_COLUMN",
+            "values": ["a", "b"],
+            "id": 3,
+        },
+    }
+
+    ctx = get_context(ctx_obj)
+
+    validations = spark_util.value_check_data(ctx, df)
+    assert sorted(validations.keys()) == ["a_long_list", "b_float_list", "c_str_list"]
+    assert [count for _, count in validations["a_long_list"]] == [1]
+    assert [count for _, count in validations["b_float_list"]] == [1]
+    assert [count for _, count in validations["c_str_list"]] == [1]
+
+
 def test_ingest_parquet_extra_cols(spark, write_parquet_file, ctx_obj, get_context):
     data = [("a", 0.1, None), ("b", 1.0, None), ("c", 1.1, 4)]
 
@@ -495,6 +650,204 @@ def test_ingest_parquet_failed_requirements(
     assert validations == {"a_str": [("(a_str IN (a, b))", 1)]}
 
 
+def test_ingest_json_nested_valid(spark, write_json_file, ctx_obj, get_context):
+    json_str = "\n".join(
+        [
+            '{"name": "a", "stats": {"score": 0.1, "visits": 1}}',
+            '{"name": "b", "stats": {"score": 1.0, "visits": 2}}',
+            '{"name": "c", "stats": {"score": 1.1}}',
+        ]
+    )
+
+    path_to_file = write_json_file(json_str)
+
+    ctx_obj["environment"] = {
+        "data": {
+            "type": "json",
+            "path": path_to_file,
+            "schema": [
+                {"json_field_path": "name", "raw_column_name": "a_str"},
+                {"json_field_path": "stats.score", "raw_column_name": "b_float"},
+                {"json_field_path": "stats.visits", "raw_column_name": "c_long"},
+            ],
+        }
+    }
+
+    ctx_obj["raw_columns"] = {
+        "a_str": {"name": "a_str", "type": "STRING_COLUMN", "required": True, "id": "1"},
+        "b_float": {"name": "b_float", "type": "FLOAT_COLUMN", "required": True, "id": "2"},
+        "c_long": {"name": "c_long", "type": "INT_COLUMN", "required": False, "id": "3"},
+    }
+
+    df = spark_util.ingest(get_context(ctx_obj), spark)
+
+    assert df.count() == 3
+    assert sorted([(s.name, s.dataType) for s in df.schema], key=lambda x: x[0]) == [
+        ("a_str", StringType()),
+        ("b_float", FloatType()),
+        ("c_long", LongType()),
+    ]
+    assert sorted(df.select("a_str", "c_long").collect()) == [
+        ("a", 1),
+        ("b", 2),
+        ("c", None),
+    ]
+
+
+def test_ingest_json_missing_field_path(spark, write_json_file, ctx_obj, get_context):
+    json_str = "\n".join(['{"name": "a", "stats": {"score": 0.1}}'])
+
+    path_to_file = write_json_file(json_str)
+
+    ctx_obj["environment"] = {
+        "data": {
+            "type": "json",
+            "path": path_to_file,
+            "schema": [
+                {"json_field_path": "name", "raw_column_name": "a_str"},
+                {"json_field_path": "stats.visits", "raw_column_name": "c_long"},
+            ],
+        }
+    }
+
+    ctx_obj["raw_columns"] = {
+        "a_str": {"name": "a_str", "type": "STRING_COLUMN", "required": True, "id": "1"},
+        "c_long": {"name": "c_long", "type": "INT_COLUMN", "required": False, "id": "3"},
+    }
+
+    with pytest.raises(UserException) as exec_info:
+        spark_util.ingest(get_context(ctx_obj), spark).collect()
+    assert "stats.visits" in str(exec_info) and "missing column" in str(exec_info)
+
+
+def test_ingest_join(spark, write_csv_file, write_json_file, ctx_obj, get_context):
+    users_path = write_csv_file("1,30\n2,40\n3,50")
+    events_path = write_json_file(
+        "\n".join(
+            [
+                '{"user": 1, "duration": 0.5}',
+                '{"user": 1, "duration": 1.5}',
+                '{"user": 2, "duration": 2.5}',
+            ]
+        )
+    )
+
+    ctx_obj["environment"] = {
+        "data": {
+            "type": "join",
+            "sources": [
+                {
+                    "name": "users",
+                    "data": {"type": "csv", "path": users_path, "schema": ["user_id", "age"]},
+                },
+                {
+                    "name": "events",
+                    "data": {
+                        "type": "json",
+                        "path": events_path,
+                        "schema": [
+                            {"json_field_path": "user", "raw_column_name": "user_id"},
+                            {"json_field_path": "duration", "raw_column_name": "duration"},
+                        ],
+                    },
+                },
+            ],
+            "joins": [{"source": "events", "keys": ["user_id"], "type": "left"}],
+        }
+    }
+
+    ctx_obj["raw_columns"] = {
+        "user_id": {"name": "user_id", "type": "INT_COLUMN", "required": True, "id": "1"},
+        "age": {"name": "age", "type": "INT_COLUMN", "required": True, "id": "2"},
+        "duration": {"name": "duration", "type": "FLOAT_COLUMN", "required": False, "id": "3"},
+    }
+
+    df = spark_util.ingest(get_context(ctx_obj), spark)
+
+    assert df.columns == ["age", "duration", "user_id"]
+    assert sorted(df.select("user_id", "age").collect()) == [(1, 30), (1, 30), (2, 40), (3, 50)]
+    assert df.filter(F.col("user_id") == 3).collect()[0]["duration"] is None
+
+
+def test_filter_rows(spark):
+    df = spark.createDataFrame(
+        [
+            Row(age=17, country="US", signup="2019-01-15"),
+            Row(age=25, country="US", signup="2019-03-01"),
+            Row(age=30, country="FR", signup="2019-03-01"),
+            Row(age=40, country="CA", signup="2019-07-01"),
+            Row(age=None, country="CA", signup="2019-02-01"),
+        ]
+    )
+
+    filter_conditions = [
+        {"column": "age", "op": "gte", "value": 18},
+        {"column": "country", "op": "in", "value": ["US", "CA"]},
+        {"column": "signup", "op": "date_range", "value": ["2019-01-01", "2019-06-30"]},
+    ]
+
+    assert spark_util.filter_rows(df, filter_conditions).collect() == [
+        Row(age=25, country="US", signup="2019-03-01")
+    ]
+
+    filter_conditions = [{"column": "country", "op": "not_in", "value": ["US"]}]
+    assert spark_util.filter_rows(df, filter_conditions).count() == 3
+
+
+def test_stratified_sample(spark):
+    data = [Row(label="a", id=i) for i in range(80)] + [Row(label="b", id=i) for i in range(20)]
+    df = spark.createDataFrame(data)
+
+    sampled_df = spark_util.stratified_sample(df, "label", 50, 100, seed=1)
+    counts = {row["label"]: row["count"] for row in sampled_df.groupBy("label").count().collect()}
+
+    assert counts == {"a": 40, "b": 10}
+    assert sorted(sampled_df.columns) == ["id", "label"]
+
+
+def test_sample_by_key(spark):
+    data = [Row(user=u, event=e) for u in range(200) for e in range(3)]
+    df = spark.createDataFrame(data)
+
+    sampled_df = spark_util.sample_by_key(df, "user", 301, seed=1)
+    events_per_user = sampled_df.groupBy("user").count().collect()
+
+    assert len(events_per_user) == 100
+    assert all(row["count"] == 3 for row in events_per_user)
+    assert sorted(sampled_df.columns) == ["event", "user"]
+    assert sorted(sampled_df.collect()) == sorted(
+        spark_util.sample_by_key(df, "user", 301, seed=1).collect()
+    )
+
+
+def test_get_weight_metadata_null_labels(spark, ctx_obj, get_context):
+    data = [Row(label=0), Row(label=0), Row(label=0), Row(label=1), Row(label=None)]
+    df = spark.createDataFrame(data, StructType([StructField("label", LongType())]))
+
+    ctx_obj["models"] = {
+        "m": {
+            "name": "m",
+            "target_column": "label",
+            "class_weight": "balanced",
+            "dataset": None,
+            "id": "-",
+        }
+    }
+    ctx = get_context(ctx_obj)
+
+    def class_distribution(data, columns, args):
+        rows = data.groupBy(columns["col"]).count().collect()
+        total = float(sum(r[1] for r in rows))
+        return {r[0]: r[1] / total for r in rows}
+
+    aggregator_impl = MagicMock()
+    aggregator_impl.aggregate_spark = class_distribution
+    ctx.get_aggregator_impl_by_name = MagicMock(return_value=(aggregator_impl, None))
+
+    metadata = spark_util.get_weight_metadata("m", df, ctx)
+    assert metadata["class_weights"] == pytest.approx({"0": 2.0 / 3, "1": 2.0})
+
+
 def test_column_names_to_index():
     sample_columns_input_config = {"b": "b_col", "a": "a_col"}
     actual_list, actual_dict = spark_util.column_names_to_index(sample_columns_input_config)
@@ -577,3 +930,256 @@ def test_run_builtin_aggregators_error(spark, ctx_obj, get_context):
 
     ctx.store_aggregate_result.assert_not_called()
     ctx.populate_args.assert_called_once_with({"ignoreNulls": "some_constant"})
+
+
+def test_handle_nulls(spark, ctx_obj, get_context):
+    data = [(1, 1.0, "a", None), (None, None, "a", 4), (3, 4.0, None, 5), (4, None, "b", 6)]
+
+    schema = StructType(
+        [
+            StructField("a_long", LongType()),
+            StructField("b_float", FloatType()),
+            StructField("c_str", StringType()),
+            StructField("d_long", LongType()),
+        ]
+    )
+
+    df = spark.createDataFrame(data, schema)
+
+    ctx_obj["raw_columns"] = {
+        "a_long": {
+            "name": "a_long",
+            "type": "INT_COLUMN",
+            "on_null": "fill",
+            "fill_aggregate": "mean",
+            "id": 1,
+        },
+        "b_float": {
+            "name": "b_float",
+            "type": "FLOAT_COLUMN",
+            "on_null": "fill",
+            "fill_value": 0.5,
+            "id": 2,
+        },
+        "c_str": {
+            "name": "c_str",
+            "type": "STRING_COLUMN",
+            "on_null": "fill",
+            "fill_aggregate": "mode",
+            "id": 3,
+        },
+        "d_long": {"name": "d_long", "type": "INT_COLUMN", "on_null": "drop", "id": 4},
+    }
+
+    result_df, fill_values = spark_util.handle_nulls(get_context(ctx_obj), df)
+
+    assert fill_values == {"a_long": 4, "b_float": 0.5, "c_str": "a"}
+    assert sorted(result_df.collect()) == [
+        Row(a_long=3, b_float=4.0, c_str="a", d_long=5),
+        Row(a_long=4, b_float=0.5, c_str="a", d_long=4),
+        Row(a_long=4, b_float=0.5, c_str="b", d_long=6),
+    ]
+
+    ctx_obj["raw_columns"]["d_long"]["on_null"] = "fail"
+    with pytest.raises(UserException):
+        spark_util.handle_nulls(get_context(ctx_obj), df)
+
+
+def test_clean_raw_dataset(spark, ctx_obj, get_context):
+    data = [
+        (1, 10, 1, "US"),
+        (None, 20, 2, "US"),
+        (3, 30, None, "US"),
+        (100, None, 4, "CA"),
+        (3, 40, 5, "US"),
+    ]
+
+    schema = StructType(
+        [
+            StructField("a_long", LongType()),
+            StructField("b_long", LongType()),
+            StructField("c_long", LongType()),
+            StructField("country", StringType()),
+        ]
+    )
+
+    df = spark.createDataFrame(data, schema)
+
+    ctx_obj["raw_columns"] = {
+        "a_long": {
+            "name": "a_long",
+            "type": "INT_COLUMN",
+            "on_null": "fill",
+            "fill_aggregate": "mean",
+            "id": 1,
+        },
+        "b_long": {"name": "b_long", "type": "INT_COLUMN", "on_null": "fail", "id": 2},
+        "c_long": {"name": "c_long", "type": "INT_COLUMN", "id": 3},
+        "country": {"name": "country", "type": "STRING_COLUMN", "id": 4},
+    }
+    ctx_obj["environment"] = {
+        "data": {"drop_null": True},
+        "filter": [{"column": "country", "op": "eq", "value": "US"}],
+    }
+
+    # dropped and filtered rows aren't checked by on_null: fail or used for the fill aggregate,
+    # and drop_null doesn't drop the row with a null value in a_long (which has on_null: fill)
+    result_df, fill_values = spark_util.clean_raw_dataset(get_context(ctx_obj), df)
+
+    assert fill_values == {"a_long": 2}
+    assert sorted(result_df.collect()) == [
+        Row(a_long=1, b_long=10, c_long=1, country="US"),
+        Row(a_long=2, b_long=20, c_long=2, country="US"),
+        Row(a_long=3, b_long=40, c_long=5, country="US"),
+    ]
+
+    ctx_obj["environment"]["filter"] = None
+    with pytest.raises(UserException):
+        spark_util.clean_raw_dataset(get_context(ctx_obj), df)
+
+
+def test_data_quality_reports(spark, ctx_obj, get_context):
+    data = [
+        ("ab", 1, None),
+        ("abc", 1, 2.0),
+        ("a1", 2, None),
+        ("abcdef", 3, None),
+        ("xyz", 4, 1.0),
+    ]
+
+    schema = StructType(
+        [
+            StructField("a_str", StringType()),
+            StructField("b_long", LongType()),
+            StructField("c_float", FloatType()),
+        ]
+    )
+
+    df = spark.createDataFrame(data, schema)
+
+    ctx_obj["raw_columns"] = {
+        "a_str": {
+            "name": "a_str",
+            "type": "STRING_COLUMN",
+            "pattern": "[a-z]+",
+            "min_length": 2,
+            "max_length": 5,
+            "error_threshold": 0.2,
+            "id": "1",
+        },
+        "b_long": {"name": "b_long", "type": "INT_COLUMN", "unique": True, "id": "2"},
+        "c_float": {
+            "name": "c_float",
+            "type": "FLOAT_COLUMN",
+            "max_null_fraction": 0.5,
+            "id": "3",
+        },
+    }
+
+    ctx = get_context(ctx_obj)
+    reports = spark_util.data_quality_reports(ctx, df, num_sample_rows=1)
+
+    # a_str's violations are each within its error threshold
+    assert reports["1"]["passed"] == True
+    assert reports["1"]["num_rows"] == 5
+    violations = {v["constraint"]: v for v in reports["1"]["violations"]}
+    assert sorted(violations.keys()) == ["max_length", "pattern"]
+    assert violations["pattern"]["num_rows"] == 1
+    assert violations["pattern"]["fraction"] == 0.2
+    assert violations["pattern"]["sample_rows"] == [{"a_str": "a1", "b_long": 2, "c_float": None}]
+    assert violations["max_length"]["sample_rows"][0]["a_str"] == "abcdef"
+
+    assert reports["2"]["passed"] == False
+    assert len(reports["2"]["violations"]) == 1
+    assert reports["2"]["violations"][0]["constraint"] == "unique"
+    assert reports["2"]["violations"][0]["num_rows"] == 1
+    assert reports["2"]["violations"][0]["sample_rows"] == [{"b_long": 1, "num_occurrences": 2}]
+
+    assert reports["3"]["passed"] == False
+    assert reports["3"]["violations"][0]["constraint"] == "max_null_fraction"
+    assert reports["3"]["violations"][0]["num_rows"] == 3
+
+    empty_df = spark.createDataFrame([], schema)
+    reports = spark_util.data_quality_reports(ctx, empty_df)
+    for report in reports.values():
+        assert report["passed"] == True
+        assert report["num_rows"] == 0
+        assert report["violations"] == []
+
+
+def test_profile_columns(spark, ctx_obj, get_context):
+    data = [("a", 1, None), ("b", 2, 0.5), ("a", 3, None), (None, 4, 0.5), ("a", 10, 1.5)]
+
+    schema = StructType(
+        [
+            StructField("a_str", StringType()),
+            StructField("b_long", LongType()),
+            StructField("c_float", FloatType()),
+        ]
+    )
+
+    df = spark.createDataFrame(data, schema)
+
+    ctx_obj["raw_columns"] = {
+        "a_str": {"name": "a_str", "type": "STRING_COLUMN", "id": "1"},
+        "b_long": {"name": "b_long", "type": "INT_COLUMN", "id": "2"},
+        "c_float": {"name": "c_float", "type": "FLOAT_COLUMN", "id": "3"},
+    }
+
+    ctx = get_context(ctx_obj)
+    profiles = spark_util.profile_columns(ctx, df, num_top_values=1, num_histogram_bins=3)
+
+    assert profiles["a_str"]["count"] == 5
+    assert profiles["a_str"]["null_count"] == 1
+    assert profiles["a_str"]["distinct_count"] == 2
+    assert profiles["a_str"]["top_values"] == [{"value": "a", "count": 3}]
+    assert "histogram" not in profiles["a_str"]
+
+    assert profiles["b_long"]["null_count"] == 0
+    assert profiles["b_long"]["min"] == 1.0
+    assert profiles["b_long"]["max"] == 10.0
+    assert profiles["b_long"]["mean"] == 4.0
+    assert profiles["b_long"]["histogram"] == [
+        {"start": 1.0, "end": 4.0, "count": 3},
+        {"start": 4.0, "end": 7.0, "count": 1},
+        {"start": 7.0, "end": 10.0, "count": 1},
+    ]
+
+    assert profiles["c_float"]["null_count"] == 2
+    assert sum(b["count"] for b in profiles["c_float"]["histogram"]) == 3
+
+
+def test_profile_columns_non_finite(spark, ctx_obj, get_context):
+    data = [(float("nan"), float("nan")), (float("inf"), 1.0), (float("-inf"), 1.0), (None, 3.0)]
+
+    schema = StructType(
+        [StructField("a_float", DoubleType()), StructField("b_float", DoubleType())]
+    )
+
+    df = spark.createDataFrame(data, schema)
+
+    ctx_obj["raw_columns"] = {
+        "a_float": {"name": "a_float", "type": "FLOAT_COLUMN", "id": "1"},
+        "b_float": {"name": "b_float", "type": "FLOAT_COLUMN", "id": "2"},
+    }
+
+    ctx = get_context(ctx_obj)
+    profiles = spark_util.profile_columns(ctx, df, num_histogram_bins=2)
+
+    # a_float has no finite values
+    assert profiles["a_float"]["null_count"] == 1
+    assert profiles["a_float"]["min"] is None
+    assert profiles["a_float"]["max"] is None
+    assert "histogram" not in profiles["a_float"]
+
+    assert profiles["b_float"]["min"] == 1.0
+    assert profiles["b_float"]["max"] == 3.0
+    assert profiles["b_float"]["histogram"] == [
+        {"start": 1.0, "end": 2.0, "count": 2},
+        {"start": 2.0, "end": 3.0, "count": 1},
+    ]
+
+    df = spark.createDataFrame([(2.0, 2.0), (2.0, float("inf"))], schema)
+    profiles = spark_util.profile_columns(ctx, df, num_histogram_bins=2)
+    assert profiles["a_float"]["histogram"] == [{"start": 2.0, "end": 2.0, "count": 2}]
+    assert profiles["b_float"]["histogram"] == [{"start": 2.0, "end": 2.0, "count": 1}]
@@ -45,6 +45,8 @@ local_cache = {
     "transform_args_cache": {},
     "required_inputs": None,
     "metadata": None,
+    "feature_baselines": None,
+    "fill_values": {},
 }
 
 DTYPE_TO_VALUE_KEY = {
@@ -60,6 +62,28 @@ DTYPE_TO_VALUE_KEY = {
 }
 
 
+DEFAULT_PREDICTION_KEYS = {
+    "classification": "class_ids",
+    "regression": "predictions",
+    "multi_label_classification": "probabilities",
+    "ranking": "predictions",
+}
+
+RESPONSE_PREDICTIONS_KEYS = {
+    "classification": "classification_predictions",
+    "regression": "regression_predictions",
+    "multi_label_classification": "multi_label_classification_predictions",
+    "ranking": "ranking_predictions",
+    "custom": "custom_predictions",
+}
+
+MULTI_TARGET_PREDICTIONS_KEY = "multi_target_predictions"
+
+MULTI_LABEL_THRESHOLD = 0.5
+
+EXPLAINABLE_MODEL_TYPES = ["classification", "regression", "ranking"]
+
+
 def transform_sample(sample):
     ctx = local_cache["ctx"]
     model = local_cache["model"]
@@ -95,7 +119,9 @@ def create_prediction_request(transformed_sample):
     prediction_request.model_spec.signature_name = signature_key
 
     for column_name, value in transformed_sample.items():
-        data_type = tf_lib.CORTEX_TYPE_TO_TF_TYPE[ctx.columns[column_name]["type"]]
+        column_type = ctx.columns[column_name]["type"]
+        data_type = tf_lib.CORTEX_TYPE_TO_TF_TYPE[column_type]
+        value = tf_lib.to_tf_value(value, column_type)
         shape = [1]
         if util.is_list(value):
             shape = [len(value)]
@@ -105,15 +131,20 @@ def create_prediction_request(transformed_sample):
     return prediction_request
 
 
-def reverse_transform(value):
+def reverse_transform(value, target_column=None):
     ctx = local_cache["ctx"]
     model = local_cache["model"]
 
-    trans_impl = local_cache["trans_impls"].get(model["target_column"], None)
+    if target_column is None:
+        target_column = model["target_column"]
+    if not target_column:
+        return None
+
+    trans_impl = local_cache["trans_impls"].get(target_column, None)
     if not (trans_impl and hasattr(trans_impl, "reverse_transform_python")):
         return None
 
-    transformer_name = model["target_column"]
+    transformer_name = target_column
     input_schema = ctx.transformed_columns[transformer_name]["inputs"]
 
     if input_schema.get("args", None) is not None and len(input_schema["args"]) > 0:
@@ -122,11 +153,11 @@ def reverse_transform(value):
         result = trans_impl.reverse_transform_python(value, args)
     except Exception as e:
         raise UserRuntimeException(
-            "transformer " + ctx.transformed_columns[model["target_column"]]["transformer"],
+            "transformer " + ctx.transformed_columns[target_column]["transformer"],
             "function reverse_transform_python",
         ) from e
 
-    return result
+    return util.isoformat_dates(result)
 
 
 def parse_response_proto(response_proto):
@@ -140,36 +171,79 @@ def parse_response_proto(response_proto):
     """
     model = local_cache["model"]
 
-    if model["type"] == "regression":
-        prediction_key = "predictions"
-    if model["type"] == "classification":
-        prediction_key = "class_ids"
-
-    if model["prediction_key"]:
-        prediction_key = model["prediction_key"]
-
     results_dict = json_format.MessageToDict(response_proto)
     outputs = results_dict["outputs"]
-    value_key = DTYPE_TO_VALUE_KEY[outputs[prediction_key]["dtype"]]
-    predicted = outputs[prediction_key][value_key][0]
 
     result = {}
     for key in outputs.keys():
         value_key = DTYPE_TO_VALUE_KEY[outputs[key]["dtype"]]
         result[key] = outputs[key][value_key]
 
-    if model["type"] == "regression":
+    if not model.get("targets"):
+        return parse_prediction(
+            result, model["type"], model["prediction_key"], model["target_column"]
+        )
+
+    # multi-head estimators prefix each head's outputs with the head name (the target column)
+    multi_target_result = {}
+    for target in model["targets"]:
+        column_name = target["column"]
+        prefix = column_name + "/"
+        target_result = {
+            key[len(prefix) :]: value for key, value in result.items() if key.startswith(prefix)
+        }
+        prediction_key = target["prediction_key"]
+        if prediction_key and prediction_key not in target_result:
+            target_result[prediction_key] = result[prediction_key]
+        multi_target_result[column_name] = parse_prediction(
+            target_result, target["type"], prediction_key, column_name
+        )
+
+    return multi_target_result
+
+
+def parse_prediction(result, model_type, prediction_key, target_column):
+    if model_type == "custom":
+        if prediction_key:
+            return {prediction_key: result[prediction_key]}
+        return result
+
+    if not prediction_key:
+        prediction_key = DEFAULT_PREDICTION_KEYS[model_type]
+
+    if model_type == "multi_label_classification":
+        # the prediction key holds per-class probabilities, each class above the threshold is predicted
+        probabilities = [float(p) for p in result[prediction_key]]
+        predicted = [i for i, p in enumerate(probabilities) if p >= MULTI_LABEL_THRESHOLD]
+        result["predicted_classes"] = predicted
+        result["predicted_classes_reversed"] = reverse_transform(predicted, target_column)
+        return result
+
+    predicted = result[prediction_key][0]
+
+    if model_type == "ranking":
+        predicted = float(predicted)
+        result["predicted_score"] = predicted
+        result["predicted_score_reversed"] = reverse_transform(predicted, target_column)
+    if model_type == "regression":
         predicted = float(predicted)
         result["predicted_value"] = predicted
-        result["predicted_value_reversed"] = reverse_transform(predicted)
-    if model["type"] == "classification":
+        result["predicted_value_reversed"] = reverse_transform(predicted, target_column)
+    if model_type == "classification":
         predicted = int(predicted)
         result["predicted_class"] = predicted
-        result["predicted_class_reversed"] = reverse_transform(predicted)
+        result["predicted_class_reversed"] = reverse_transform(predicted, target_column)
 
     return result
 
 
+def rank_predictions(predictions):
+    """rank the samples in the request by descending score"""
+    ranked = sorted(range(len(predictions)), key=lambda i: -predictions[i]["predicted_score"])
+    for rank, i in enumerate(ranked):
+        predictions[i]["rank"] = rank + 1
+
+
 def create_get_model_metadata_request():
     get_model_metadata_request = get_model_metadata_pb2.GetModelMetadataRequest()
     get_model_metadata_request.model_spec.name = "default"
@@ -187,11 +261,59 @@ def run_get_model_metadata():
     return sigmap
 
 
-def run_predict(sample):
+def get_prediction_score(result, predicted_class=None):
+    """Returns the predicted value or score (the predicted class's probability for classification)"""
+    model_type = local_cache["model"]["type"]
+    if model_type == "classification":
+        if "probabilities" not in result:
+            raise UserException("explain", "the model's predictions must include probabilities")
+        return float(result["probabilities"][predicted_class])
+    if model_type == "regression":
+        return result["predicted_value"]
+    return result["predicted_score"]
+
+
+def explain_prediction(transformed_sample, result):
+    """
+    The contribution of each feature column is the change in the prediction's score when the
+    column's value is replaced with its baseline (computed during training)
+    """
+    predicted_class = result.get("predicted_class")
+    score = get_prediction_score(result, predicted_class)
+
+    contributions = {}
+    for column_name, baseline in local_cache["feature_baselines"].items():
+        replaced_sample = dict(transformed_sample)
+        replaced_sample[column_name] = baseline
+        prediction_request = create_prediction_request(replaced_sample)
+        response_proto = local_cache["stub"].Predict(prediction_request, timeout=10.0)
+        replaced_result = parse_response_proto(response_proto)
+        contributions[column_name] = score - get_prediction_score(replaced_result, predicted_class)
+    return contributions
+
+
+def explain_unsupported_reason():
+    model = local_cache["model"]
+    if model.get("targets") or model["type"] not in EXPLAINABLE_MODEL_TYPES:
+        return "explanations are only supported for {} models with a single target".format(
+            ", ".join(EXPLAINABLE_MODEL_TYPES)
+        )
+    if not model["evaluation"]["feature_importance"]:
+        return "model {} must enable evaluation.feature_importance to explain predictions".format(
+            model["name"]
+        )
+    if local_cache["feature_baselines"] is None:
+        return "feature importance was not computed for model {}".format(model["name"])
+    return None
+
+
+def run_predict(sample, explain=False):
     transformed_sample = transform_sample(sample)
     prediction_request = create_prediction_request(transformed_sample)
     response_proto = local_cache["stub"].Predict(prediction_request, timeout=10.0)
     result = parse_response_proto(response_proto)
+    if explain:
+        result["feature_contributions"] = explain_prediction(transformed_sample, result)
     util.log_indent("Raw sample:", indent=4)
     util.log_pretty(sample, indent=6)
     util.log_indent("Transformed sample:", indent=4)
@@ -202,6 +324,14 @@ def run_predict(sample):
     return result
 
 
+def fill_nulls(sample):
+    """Replace missing and null values using the same fill values as ingestion"""
+    for column in local_cache["required_inputs"]:
+        column_name = column["name"]
+        if sample.get(column_name) is None and column_name in local_cache["fill_values"]:
+            sample[column_name] = local_cache["fill_values"][column_name]
+
+
 def is_valid_sample(sample):
     for column in local_cache["required_inputs"]:
         if column["name"] not in sample:
@@ -217,7 +347,7 @@ def is_valid_sample(sample):
 
 
 def prediction_failed(sample, reason=None):
-    message = "prediction failed for sample: {}".format(json.dumps(sample))
+    message = "prediction failed for sample: {}".format(json.dumps(sample, default=str))
     if reason:
         message += " ({})".format(reason)
 
@@ -246,6 +376,12 @@ def predict(app_name, api_name):
         util.log_pretty(payload, logging_func=logger.error)
         return prediction_failed(payload, "top level `samples` key not found in request")
 
+    explain = payload.get("explain", False)
+    if explain:
+        reason = explain_unsupported_reason()
+        if reason:
+            return prediction_failed(payload, reason)
+
     logger.info("Predicting " + util.pluralize(len(payload["samples"]), "sample", "samples"))
 
     predictions = []
@@ -259,6 +395,7 @@ def predict(app_name, api_name):
     for i, sample in enumerate(payload["samples"]):
         util.log_indent("sample {}".format(i + 1), 2)
 
+        fill_nulls(sample)
         is_valid, reason = is_valid_sample(sample)
         if not is_valid:
             return prediction_failed(sample, reason)
@@ -267,7 +404,7 @@ def predict(app_name, api_name):
             sample[column["name"]] = util.upcast(sample[column["name"]], column["type"])
 
         try:
-            result = run_predict(sample)
+            result = run_predict(sample, explain)
         except CortexException as e:
             e.wrap("error", "sample {}".format(i + 1))
             logger.error(str(e))
@@ -283,10 +420,15 @@ def predict(app_name, api_name):
 
         predictions.append(result)
 
-    if model["type"] == "regression":
-        response["regression_predictions"] = predictions
-    if model["type"] == "classification":
-        response["classification_predictions"] = predictions
+    if model.get("targets"):
+        for target in model["targets"]:
+            if target["type"] == "ranking":
+                rank_predictions([prediction[target["column"]] for prediction in predictions])
+        response[MULTI_TARGET_PREDICTIONS_KEY] = predictions
+    else:
+        if model["type"] == "ranking":
+            rank_predictions(predictions)
+        response[RESPONSE_PREDICTIONS_KEYS[model["type"]]] = predictions
 
     response["resource_id"] = api["id"]
 
@@ -308,7 +450,9 @@ def start(args):
     if not os.path.isdir(args.model_dir):
         ctx.storage.download_and_unzip(model["key"], args.model_dir)
 
-    for column_name in model["feature_columns"] + [model["target_column"]]:
+    column_names = model["feature_columns"] + ctx.get_target_column_names(model["name"])
+
+    for column_name in column_names:
         if ctx.is_transformed_column(column_name):
             trans_impl, _ = ctx.get_transformer_impl(column_name)
             local_cache["trans_impls"][column_name] = trans_impl
@@ -320,11 +464,28 @@ def start(args):
                     input_args_schema
                 )
 
+    # baselines are saved with the model's feature importance, and are used to explain predictions
+    if model["evaluation"]["feature_importance"]:
+        if ctx.storage.search(prefix=model["importance_key"]) != []:
+            feature_importance = ctx.storage.get_json(model["importance_key"])
+            local_cache["feature_baselines"] = feature_importance["baselines"]
+
     channel = implementations.insecure_channel("localhost", args.tf_serve_port)
     local_cache["stub"] = prediction_service_pb2.beta_create_PredictionService_stub(channel)
 
     local_cache["required_inputs"] = tf_lib.get_base_input_columns(model["name"], ctx)
 
+    fill_columns = [
+        column for column in local_cache["required_inputs"] if column.get("on_null") == "fill"
+    ]
+    if len(fill_columns) > 0:
+        raw_dataset_metadata = ctx.storage.get_json(ctx.raw_dataset["metadata_key"])
+        fill_values = raw_dataset_metadata.get("fill_values", {})
+        for column in fill_columns:
+            local_cache["fill_values"][column["name"]] = fill_values.get(
+                column["name"], column.get("fill_value")
+            )
+
     # wait a bit for tf serving to start before querying metadata
     limit = 600
     for i in range(limit):
@@ -14,12 +14,15 @@
 
 import sys
 import os
+import shutil
+import time
 import argparse
 import traceback
 import tensorflow as tf
 
 from lib import util, package, Context
-from lib.exceptions import CortexException, UserRuntimeException
+from lib.storage import S3
+from lib.exceptions import CortexException, UserException, UserRuntimeException
 import train_util
 
 from lib.log import get_logger
@@ -27,6 +30,70 @@ from lib.log import get_logger
 logger = get_logger()
 
 
+def import_external_model(model, temp_dir):
+    """Download an external SavedModel export into the directory layout expected by TF Serving"""
+    external_path = model["external_model"]["path"]
+    bucket, prefix = S3.deconstruct_s3_path(external_path)
+    export_dir = os.path.join(temp_dir, "external_export")
+    S3(bucket=bucket, client_config={}).download_dir(prefix, export_dir)
+
+    # TF Serving expects the SavedModel to be nested in a numbered version directory
+    if os.path.isfile(os.path.join(export_dir, "saved_model.pb")):
+        versioned_export_dir = os.path.join(temp_dir, "versioned_external_export")
+        util.mkdir_p(versioned_export_dir)
+        shutil.move(export_dir, os.path.join(versioned_export_dir, "1"))
+        return versioned_export_dir
+
+    for version_dir in os.listdir(export_dir):
+        if os.path.isfile(os.path.join(export_dir, version_dir, "saved_model.pb")):
+            return export_dir
+
+    raise UserException(
+        "model " + model["name"],
+        "external_model",
+        "no saved_model.pb found at " + external_path,
+    )
+
+
+def start_attempt(ctx, attempts_key):
+    """Record the start of an attempt of this workload, and return the attempt number (starting at 1)"""
+    attempts = ctx.storage.get_json(attempts_key, allow_missing=True)
+    attempt = 1
+    if attempts is not None and attempts["workload_id"] == ctx.workload_id:
+        attempt = attempts["attempt"] + 1
+    ctx.storage.put_json({"workload_id": ctx.workload_id, "attempt": attempt}, attempts_key)
+    return attempt
+
+
+def model_run(ctx, model, start, metrics):
+    """Build the entry which is appended to the model's run history"""
+    return {
+        "model_id": model["id"],
+        "model_name": model["name"],
+        "workload_id": ctx.workload_id,
+        "dataset_version": ctx.dataset_version,
+        "hparams": model["hparams"],
+        "feature_columns": model["feature_columns"],
+        "compute": model["compute"],
+        "start": start,
+        "end": util.now_timestamp_rfc_3339(),
+        "metrics": metrics,
+    }
+
+
+def model_manifest(ctx, model):
+    """Complete the model's manifest with the workloads which produced it"""
+    manifest = dict(model["manifest"])
+    workload_ids = {ctx.workload_id, model["dataset"]["workload_id"]}
+    for column_name in manifest["columns"]:
+        workload_ids.add(ctx.columns[column_name]["workload_id"])
+    for aggregate_name in manifest["aggregates"]:
+        workload_ids.add(ctx.aggregates[aggregate_name]["workload_id"])
+    manifest["workload_ids"] = sorted(workload_ids)
+    manifest["trained_at"] = util.now_timestamp_rfc_3339()
+    return manifest
+
+
 def train(args):
     ctx = Context(s3_path=args.context, cache_dir=args.cache_dir, workload_id=args.workload_id)
 
@@ -34,28 +101,78 @@ def train(args):
 
     model = ctx.models_id_map[args.model]
 
-    logger.info("Training")
+    if model["external_model"] is not None:
+        logger.info("Importing")
+    elif args.fold is not None:
+        logger.info("Training fold {} of {}".format(args.fold + 1, len(model["fold_metrics_keys"])))
+    else:
+        logger.info("Training")
+
+    checkpoint_key = model["checkpoint_key"]
+    if args.fold is not None:
+        fold_dir = os.path.dirname(model["fold_metrics_keys"][args.fold])
+        checkpoint_key = os.path.join(fold_dir, "checkpoint.zip")
+
+    max_attempts = model["retry"]["max_retries"] + 1
+    attempt = start_attempt(ctx, os.path.join(os.path.dirname(checkpoint_key), "attempts.json"))
+    if attempt > 1:
+        backoff_secs = model["retry"]["backoff_secs"] * 2 ** (attempt - 2)
+        logger.info(
+            "Retrying (attempt {} of {}) in {} seconds".format(attempt, max_attempts, backoff_secs)
+        )
+        time.sleep(backoff_secs)
 
     with util.Tempdir(ctx.cache_dir) as temp_dir:
         model_dir = os.path.join(temp_dir, "model_dir")
-        ctx.upload_resource_status_start(model)
+        # fold workloads don't own the model's status
+        if args.fold is None:
+            ctx.upload_resource_status_attempt_start(model, attempt, max_attempts)
 
         try:
-            model_impl = ctx.get_model_impl(model["name"])
-            train_util.train(model["name"], model_impl, ctx, model_dir)
+            if args.fold is not None:
+                model_impl = ctx.get_model_impl(model["name"])
+                metrics = train_util.train(
+                    model["name"], model_impl, ctx, model_dir, args.fold, checkpoint_key
+                )
+                util.log_pretty(metrics)
+                ctx.storage.put_json(metrics, model["fold_metrics_keys"][args.fold])
+                util.log_job_finished(ctx.workload_id)
+                return
+
+            if model["external_model"] is not None:
+                model_export_dir = import_external_model(model, temp_dir)
+            else:
+                model_impl = ctx.get_model_impl(model["name"])
+                start = util.now_timestamp_rfc_3339()
+                metrics = train_util.train(
+                    model["name"], model_impl, ctx, model_dir, checkpoint_key=checkpoint_key
+                )
+                run = model_run(ctx, model, start, metrics)
+                model_export_dir = os.path.join(model_dir, "export", "estimator")
             ctx.upload_resource_status_success(model)
 
             logger.info("Caching")
             logger.info("Caching model " + model["name"])
-            model_export_dir = os.path.join(model_dir, "export", "estimator")
             model_zip_path = os.path.join(temp_dir, "model.zip")
             util.zip_dir(model_export_dir, model_zip_path)
 
             ctx.storage.upload_file(model_zip_path, model["key"])
+
+            if model["external_model"] is None:
+                # record the trained model so that later models can warm-start from it
+                for lineage_key in model["lineage_keys"]:
+                    ctx.storage.put_json(model["lineage"], lineage_key)
+                run_key = os.path.join(
+                    model["runs_prefix"], "{}-{}.json".format(model["id"], ctx.workload_id)
+                )
+                ctx.storage.put_json(run, run_key)
+                ctx.storage.put_json(model_manifest(ctx, model), model["manifest_key"])
+
             util.log_job_finished(ctx.workload_id)
 
         except CortexException as e:
-            ctx.upload_resource_status_failed(model)
+            if attempt >= max_attempts:
+                ctx.upload_resource_status_failed(model)
             e.wrap("error")
             logger.error(str(e))
             logger.exception(
@@ -63,7 +180,8 @@ def train(args):
             )
             sys.exit(1)
         except Exception as e:
-            ctx.upload_resource_status_failed(model)
+            if attempt >= max_attempts:
+                ctx.upload_resource_status_failed(model)
             logger.exception(
                 "An error occurred, see `cx logs model {}` for more details.".format(model["name"])
             )
@@ -81,6 +199,9 @@ def main():
     )
     na.add_argument("--cache-dir", required=True, help="Local path for the context cache")
     na.add_argument("--model", required=True, help="Resource id of the model to train")
+    parser.add_argument(
+        "--fold", type=int, help="Index of the held-out fold for k-fold cross-validation"
+    )
     parser.set_defaults(func=train)
 
     args = parser.parse_args()
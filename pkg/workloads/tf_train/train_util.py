@@ -14,14 +14,21 @@
 
 import os
 import sys
+import json
 import inspect
 import importlib
 import multiprocessing
 import math
+import time
 import tensorflow as tf
+from collections import Counter
 
+import consts
 from lib import util, tf_lib
-from lib.exceptions import UserRuntimeException
+from lib.exceptions import UserException, UserRuntimeException
+from lib.log import get_logger
+
+logger = get_logger()
 
 
 def get_input_placeholder(model_name, ctx, training=True):
@@ -36,6 +43,15 @@ def get_input_placeholder(model_name, ctx, training=True):
 def get_label_placeholder(model_name, ctx):
     model = ctx.models[model_name]
 
+    if ctx.is_multi_target(model_name):
+        return {
+            target_column_name: tf.placeholder(
+                shape=[None],
+                dtype=tf_lib.CORTEX_TYPE_TO_TF_TYPE[ctx.columns[target_column_name]["type"]],
+            )
+            for target_column_name in ctx.get_target_column_names(model_name)
+        }
+
     target_column_name = model["target_column"]
     column_type = tf_lib.CORTEX_TYPE_TO_TF_TYPE[ctx.columns[target_column_name]["type"]]
     return tf.placeholder(shape=[None], dtype=column_type)
@@ -58,17 +74,100 @@ def generate_example_parsing_fn(model_name, ctx, training=True):
 
     def _parse_example(example_proto):
         features = tf.parse_single_example(serialized=example_proto, features=feature_spec)
-        target = features.pop(model["target_column"], None)
+        if ctx.is_multi_target(model_name):
+            target = {
+                target_column_name: features.pop(target_column_name, None)
+                for target_column_name in ctx.get_target_column_names(model_name)
+            }
+        else:
+            target = features.pop(model["target_column"], None)
         return features, target
 
     return _parse_example
 
 
+def get_example_weight_fn(model_name, ctx, dataset_metadata):
+    """Returns a function which adds each example's weight as a feature (or None if the model's examples aren't weighted)"""
+    model = ctx.models[model_name]
+    if not model["weight_column"] and model["class_weight"] is None:
+        return None
+    # class weights are keyed by the label as a string, and non-integer labels (e.g. "None") are skipped
+    class_weights = {
+        int(label): class_weight
+        for label, class_weight in dataset_metadata.get("class_weights", {}).items()
+        if label.lstrip("-").isdigit()
+    }
+
+    def _add_example_weight(features, target):
+        weight = tf.constant(1.0, dtype=tf.float32)
+        if model["weight_column"]:
+            weight = tf.cast(features[model["weight_column"]], tf.float32)
+        if class_weights:
+            weight *= tf.add_n(
+                [
+                    tf.cast(tf.equal(target, label), tf.float32) * class_weight
+                    for label, class_weight in class_weights.items()
+                ]
+            )
+        features = dict(features)
+        features[consts.EXAMPLE_WEIGHT_COLUMN] = weight
+        return features, target
+
+    return _add_example_weight
+
+
+def get_estimator_head(estimator):
+    """Returns the head of a canned estimator (or None if the estimator doesn't have one)"""
+    model_fn = estimator._model_fn
+    free_vars = dict(zip(model_fn.__code__.co_freevars, model_fn.__closure__ or ()))
+    if "head" not in free_vars:
+        return None
+    head = free_vars["head"].cell_contents
+    if not hasattr(head, "_weight_column"):
+        return None
+    return head
+
+
+def add_example_weights(model_name, estimator):
+    """Returns an estimator which applies each example's weight (the EXAMPLE_WEIGHT_COLUMN feature)
+
+    The weight column of canned estimators is set automatically; other estimators must use
+    model_config["example_weight_column"] in their loss, otherwise a UserException is raised
+    """
+    head = get_estimator_head(estimator)
+    if head is not None and head._weight_column is None:
+        head._weight_column = consts.EXAMPLE_WEIGHT_COLUMN
+
+    def _model_fn(features, labels, mode, config):
+        spec = estimator.model_fn(features, labels, mode, config)
+        if mode == tf.estimator.ModeKeys.PREDICT:
+            return spec
+
+        # the weight is unused if nothing in the model's graph consumes it
+        weight = features.get(consts.EXAMPLE_WEIGHT_COLUMN)
+        if weight is None or len(weight.consumers()) == 0:
+            raise UserException(
+                "model " + model_name,
+                "the estimator ignores the example weights (set its weight_column to "
+                + 'model_config["example_weight_column"], and make sure transform_tensorflow '
+                + "doesn't remove the {} feature)".format(consts.EXAMPLE_WEIGHT_COLUMN),
+            )
+        return spec
+
+    return tf.estimator.Estimator(
+        model_fn=_model_fn, model_dir=estimator.model_dir, config=estimator.config
+    )
+
+
 # Mode must be "training" or "evaluation"
-def generate_input_fn(model_name, ctx, mode, model_impl):
+# If the model has a weight_column or class_weight, each example's weight is added as the EXAMPLE_WEIGHT_COLUMN feature
+# If permuted_column is specified, that column's values are shuffled across examples (for feature importance)
+def generate_input_fn(
+    model_name, ctx, mode, model_impl, dataset_metadata, fold=None, permuted_column=None
+):
     model = ctx.models[model_name]
 
-    filenames = ctx.get_training_data_parts(model_name, mode)
+    filenames = ctx.get_training_data_parts(model_name, mode, fold=fold)
     filenames = [ctx.storage.blob_path(f) for f in filenames]
 
     num_threads = multiprocessing.cpu_count()
@@ -81,6 +180,13 @@ def generate_input_fn(model_name, ctx, mode, model_impl):
             num_parallel_calls=num_threads,
         )
 
+        example_weight_fn = get_example_weight_fn(model_name, ctx, dataset_metadata)
+        if example_weight_fn is not None:
+            dataset = dataset.map(example_weight_fn, num_parallel_calls=num_threads)
+
+        if permuted_column is not None:
+            dataset = permute_column(dataset, permuted_column)
+
         if model[mode]["shuffle"]:
             dataset = dataset.shuffle(buffer_size)
 
@@ -98,6 +204,96 @@ def generate_input_fn(model_name, ctx, mode, model_impl):
     return _input_fn
 
 
+PERMUTATION_BUFFER_SIZE = 10000
+
+
+def permute_column(dataset, column_name):
+    """Replaces each example's value of column_name with the value from another (random) example"""
+    shuffled_values = dataset.map(lambda features, target: features[column_name])
+    shuffled_values = shuffled_values.shuffle(PERMUTATION_BUFFER_SIZE)
+
+    def _replace_column(example, shuffled_value):
+        features, target = example
+        features = dict(features)
+        features[column_name] = shuffled_value
+        return features, target
+
+    return tf.data.Dataset.zip((dataset, shuffled_values)).map(_replace_column)
+
+
+def get_feature_importance(
+    model_name, ctx, model_impl, estimator, eval_results, dataset_metadata, fold, steps
+):
+    """Returns the increase in evaluation loss when each feature column is permuted"""
+    if "loss" not in eval_results:
+        raise UserException(
+            "model " + model_name,
+            "evaluation.feature_importance",
+            "the model's evaluation metrics must include loss",
+        )
+
+    model = ctx.models[model_name]
+    importance = {}
+    for column_name in model["feature_columns"]:
+        logger.info("Evaluating with {} permuted".format(column_name))
+        permuted_input_fn = generate_input_fn(
+            model_name,
+            ctx,
+            "evaluation",
+            model_impl,
+            dataset_metadata,
+            fold,
+            permuted_column=column_name,
+        )
+        permuted_results = estimator.evaluate(
+            permuted_input_fn, steps=steps, name="permuted_" + column_name
+        )
+        importance[column_name] = float(permuted_results["loss"] - eval_results["loss"])
+    return importance
+
+
+FEATURE_BASELINE_SAMPLE_SIZE = 1000
+
+
+def get_feature_baselines(model_name, ctx, fold=None):
+    """Returns the value each feature column is replaced with to explain predictions
+
+    Baselines are computed from the evaluation data: the mean of float columns, and the most common
+    value of other columns (list columns are replaced with an empty list)
+    """
+    model = ctx.models[model_name]
+    filenames = ctx.get_training_data_parts(model_name, "evaluation", fold=fold)
+    filenames = [ctx.storage.blob_path(f) for f in filenames]
+
+    samples = {column_name: [] for column_name in model["feature_columns"]}
+    with tf.Graph().as_default():
+        dataset = tf.data.TFRecordDataset(filenames=filenames)
+        dataset = dataset.map(generate_example_parsing_fn(model_name, ctx, training=True))
+        dataset = dataset.take(FEATURE_BASELINE_SAMPLE_SIZE)
+        features, _ = dataset.make_one_shot_iterator().get_next()
+        with tf.Session() as sess:
+            while True:
+                try:
+                    values = sess.run({name: features[name] for name in samples})
+                except tf.errors.OutOfRangeError:
+                    break
+                for column_name, value in values.items():
+                    samples[column_name].append(value)
+
+    baselines = {}
+    for column_name, values in samples.items():
+        if ctx.columns[column_name]["type"] in consts.COLUMN_LIST_TYPES or len(values) == 0:
+            baselines[column_name] = []
+            continue
+        values = [v.item() if hasattr(v, "item") else v for v in values]
+        values = [v.decode("utf-8") if isinstance(v, bytes) else v for v in values]
+        if isinstance(values[0], float):
+            baselines[column_name] = sum(values) / len(values)
+        else:
+            baselines[column_name] = Counter(values).most_common(1)[0][0]
+    return baselines
+
+
 def generate_json_serving_input_fn(model_name, ctx, model_impl):
     def _json_serving_input_fn():
         inputs = get_input_placeholder(model_name, ctx, training=False)
@@ -124,12 +320,184 @@ def get_regression_eval_metrics(labels, predictions):
     return metrics
 
 
-def train(model_name, model_impl, ctx, model_dir):
+def get_dataset_sizes(dataset_metadata, fold=None):
+    """Returns the number of training and evaluation samples (for the held-out fold if fold is specified)"""
+    if fold is None:
+        return dataset_metadata["training_size"], dataset_metadata["eval_size"]
+
+    fold_sizes = dataset_metadata["fold_sizes"]
+    return sum(fold_sizes) - fold_sizes[fold], fold_sizes[fold]
+
+
+class CheckpointUploaderHook(tf.train.SessionRunHook):
+    """Uploads the model directory whenever a new checkpoint is saved, so that a retried job can resume from it"""
+
+    def __init__(self, ctx, model_dir, checkpoint_key, min_interval_secs=60):
+        self._ctx = ctx
+        self._model_dir = model_dir
+        self._checkpoint_key = checkpoint_key
+        self._min_interval_secs = min_interval_secs
+        self._last_checkpoint = tf.train.latest_checkpoint(model_dir)
+        self._last_upload_time = time.time()
+
+    def after_run(self, run_context, run_values):
+        if time.time() - self._last_upload_time >= self._min_interval_secs:
+            self._upload_latest_checkpoint()
+
+    def end(self, session):
+        self._upload_latest_checkpoint()
+
+    def _upload_latest_checkpoint(self):
+        self._last_upload_time = time.time()
+        latest_checkpoint = tf.train.latest_checkpoint(self._model_dir)
+        if latest_checkpoint is None or latest_checkpoint == self._last_checkpoint:
+            return
+        self._ctx.storage.zip_and_upload(self._model_dir, self._checkpoint_key)
+        self._last_checkpoint = latest_checkpoint
+
+
+class SummaryUploaderHook(tf.train.SessionRunHook):
+    """Uploads new TensorBoard event files from the model directory, so that they can be viewed during training"""
+
+    def __init__(self, ctx, model_dir, summaries_dir, min_interval_secs=30):
+        self._ctx = ctx
+        self._model_dir = model_dir
+        self._summaries_dir = summaries_dir
+        self._min_interval_secs = min_interval_secs
+        self._uploaded_sizes = {}
+        self._last_upload_time = time.time()
+
+    def after_run(self, run_context, run_values):
+        if time.time() - self._last_upload_time >= self._min_interval_secs:
+            self.upload()
+
+    def end(self, session):
+        self.upload()
+
+    def upload(self):
+        self._last_upload_time = time.time()
+        for dirpath, _, filenames in os.walk(self._model_dir):
+            for filename in filenames:
+                if not filename.startswith("events.out.tfevents"):
+                    continue
+                path = os.path.join(dirpath, filename)
+                size = os.path.getsize(path)
+                if self._uploaded_sizes.get(path) == size:
+                    continue
+                key = os.path.join(self._summaries_dir, os.path.relpath(path, self._model_dir))
+                self._ctx.storage.upload_file(path, key)
+                self._uploaded_sizes[path] = size
+
+
+def restore_checkpoint(ctx, model_dir, checkpoint_key):
+    """Downloads the model directory saved by CheckpointUploaderHook, returns True if a checkpoint was restored"""
+    if ctx.storage.search(prefix=checkpoint_key) == []:
+        return False
+
+    ctx.storage.download_and_unzip(checkpoint_key, model_dir)
+
+    # checkpoint state files contain absolute paths, which must point to the new model directory
+    checkpoint_state = tf.train.get_checkpoint_state(model_dir)
+    if checkpoint_state is None:
+        return False
+    tf.train.update_checkpoint_state(
+        model_dir,
+        os.path.join(model_dir, os.path.basename(checkpoint_state.model_checkpoint_path)),
+        all_model_checkpoint_paths=[
+            os.path.join(model_dir, os.path.basename(path))
+            for path in checkpoint_state.all_model_checkpoint_paths
+        ],
+    )
+    return True
+
+
+WARM_START_FILENAME = "warm_start.json"
+
+
+def get_warm_start_steps(ctx, model, model_dir, restored):
+    """Returns the number of steps the source model was trained for (or 0 if the model isn't warm-started)"""
+    warm_start_checkpoint_key = model["lineage"]["warm_start_checkpoint_key"]
+    if not warm_start_checkpoint_key:
+        return 0
+
+    # the number of warm start steps is saved alongside the checkpoints, so that retries resume correctly
+    warm_start_path = os.path.join(model_dir, WARM_START_FILENAME)
+    if restored and os.path.isfile(warm_start_path):
+        with open(warm_start_path) as f:
+            return json.load(f)["steps"]
+
+    util.rm_dir(model_dir)
+    util.mkdir_p(model_dir)
+    if not restore_checkpoint(ctx, model_dir, warm_start_checkpoint_key):
+        raise UserException(
+            "warm_start",
+            "no checkpoint was found for model {}".format(model["lineage"]["warm_start_model_id"]),
+        )
+    # only keep the checkpoint (e.g. not the source model's exports or event files)
+    for filename in os.listdir(model_dir):
+        if not filename.startswith("model.ckpt") and filename != "checkpoint":
+            path = os.path.join(model_dir, filename)
+            if os.path.isdir(path):
+                util.rm_dir(path)
+            else:
+                util.rm_file(path)
+
+    steps = int(tf.train.load_variable(model_dir, tf.GraphKeys.GLOBAL_STEP))
+    with open(warm_start_path, "w") as f:
+        json.dump({"steps": steps}, f)
+    logger.info(
+        "Warm-starting from model {} (trained for {} steps)".format(
+            model["lineage"]["warm_start_model_id"], steps
+        )
+    )
+    return steps
+
+
+def last_eval_metrics(estimator):
+    """Read the metrics of the latest evaluation from the summaries it wrote"""
+    metrics = {}
+    last_step = -1
+    for events_file in tf.gfile.Glob(os.path.join(estimator.eval_dir(), "events.out.tfevents.*")):
+        for event in tf.train.summary_iterator(events_file):
+            if not event.HasField("summary") or event.step < last_step:
+                continue
+            if event.step > last_step:
+                last_step = event.step
+                metrics = {}
+            for value in event.summary.value:
+                if value.HasField("simple_value"):
+                    metrics[value.tag] = value.simple_value
+    return metrics
+
+
+# For k-fold cross-validation, fold is the index of the held-out fold (or None for the full-data fit)
+# If checkpoint_key is specified, training resumes from (and periodically saves) the checkpoint at that key
+# Returns the final evaluation metrics
+def train(model_name, model_impl, ctx, model_dir, fold=None, checkpoint_key=None):
     model = ctx.models[model_name]
 
     util.mkdir_p(model_dir)
     util.rm_dir(model_dir)
 
+    hooks = []
+    restored = False
+    if checkpoint_key is not None:
+        util.mkdir_p(model_dir)
+        restored = restore_checkpoint(ctx, model_dir, checkpoint_key)
+        if restored:
+            logger.info("Resuming from the latest checkpoint")
+        hooks.append(CheckpointUploaderHook(ctx, model_dir, checkpoint_key))
+
+    # each fold is shown as a separate run in TensorBoard
+    summaries_dir = model["summaries_dir"]
+    if fold is not None:
+        summaries_dir = os.path.join(summaries_dir, "fold_{}".format(fold))
+    summary_uploader = SummaryUploaderHook(ctx, model_dir, summaries_dir)
+    hooks.append(summary_uploader)
+
+    # warm-started models continue training from the source model's final checkpoint
+    warm_start_steps = get_warm_start_steps(ctx, model, model_dir, restored)
+
     tf_lib.set_logging_verbosity(ctx.environment["log_level"]["tensorflow"])
 
     run_config = tf.estimator.RunConfig(
@@ -143,25 +511,33 @@ def train(model_name, model_impl, ctx, model_dir):
         model_dir=model_dir,
     )
 
-    train_input_fn = generate_input_fn(model_name, ctx, "training", model_impl)
-    eval_input_fn = generate_input_fn(model_name, ctx, "evaluation", model_impl)
+    dataset_metadata = ctx.storage.get_json(model["dataset"]["metadata_key"])
+
+    train_input_fn = generate_input_fn(
+        model_name, ctx, "training", model_impl, dataset_metadata, fold
+    )
+    eval_input_fn = generate_input_fn(
+        model_name, ctx, "evaluation", model_impl, dataset_metadata, fold
+    )
     serving_input_fn = generate_json_serving_input_fn(model_name, ctx, model_impl)
     exporter = tf.estimator.FinalExporter("estimator", serving_input_fn, as_text=False)
 
-    dataset_metadata = ctx.storage.get_json(model["dataset"]["metadata_key"])
+    training_size, eval_size = get_dataset_sizes(dataset_metadata, fold)
     train_num_steps = model["training"]["num_steps"]
     if model["training"]["num_epochs"]:
         train_num_steps = (
-            math.ceil(dataset_metadata["training_size"] / float(model["training"]["batch_size"]))
+            math.ceil(training_size / float(model["training"]["batch_size"]))
             * model["training"]["num_epochs"]
         )
 
-    train_spec = tf.estimator.TrainSpec(train_input_fn, max_steps=train_num_steps)
+    train_spec = tf.estimator.TrainSpec(
+        train_input_fn, max_steps=warm_start_steps + train_num_steps, hooks=hooks
+    )
 
     eval_num_steps = model["evaluation"]["num_steps"]
     if model["evaluation"]["num_epochs"]:
         eval_num_steps = (
-            math.ceil(dataset_metadata["eval_size"] / float(model["evaluation"]["batch_size"]))
+            math.ceil(eval_size / float(model["evaluation"]["batch_size"]))
             * model["evaluation"]["num_epochs"]
         )
 
@@ -182,9 +558,37 @@ def train(model_name, model_impl, ctx, model_dir):
     except Exception as e:
         raise UserRuntimeException("model " + model_name) from e
 
-    if model["type"] == "regression":
+    if model["weight_column"] or model["class_weight"] is not None:
+        estimator = add_example_weights(model_name, estimator)
+
+    if model["type"] == "regression" and not ctx.is_multi_target(model_name):
         estimator = tf.contrib.estimator.add_metrics(estimator, get_regression_eval_metrics)
 
-    tf.estimator.train_and_evaluate(estimator, train_spec, eval_spec)
+    train_and_evaluate_results = tf.estimator.train_and_evaluate(estimator, train_spec, eval_spec)
+
+    # in local mode, train_and_evaluate returns the final evaluation's metrics (and export results)
+    if train_and_evaluate_results is not None and train_and_evaluate_results[0] is not None:
+        eval_results = train_and_evaluate_results[0]
+    else:
+        eval_results = last_eval_metrics(estimator)
+    summary_uploader.upload()  # the final evaluation's summaries are written after training ends
+
+    if fold is None and model["evaluation"]["feature_importance"]:
+        logger.info("Computing feature importance")
+        importance = get_feature_importance(
+            model_name,
+            ctx,
+            model_impl,
+            estimator,
+            eval_results,
+            dataset_metadata,
+            fold,
+            eval_num_steps,
+        )
+        feature_importance = {
+            "importance": importance,
+            "baselines": get_feature_baselines(model_name, ctx, fold),
+        }
+        ctx.storage.put_json(feature_importance, model["importance_key"])
 
-    return model_dir
+    return {name: float(value) for name, value in eval_results.items() if name != "global_step"}